@@ -0,0 +1,74 @@
+package main
+
+import (
+	"flag"
+	"io"
+
+	"github.com/pamburus/go-ansi-esc/sgrhtml"
+	"github.com/pamburus/go-ansi-esc/sgrsvg"
+)
+
+// ---
+
+func setupHTML(fs *flag.FlagSet) func(*env) error {
+	classes := fs.Bool("classes", false, "use CSS classes instead of inline styles")
+	document := fs.Bool("document", false, "produce a complete HTML document including a stylesheet")
+	title := fs.String("title", "", "title of the HTML document")
+
+	return func(e *env) error {
+		var options []sgrhtml.Option
+		if *classes {
+			options = append(options, sgrhtml.WithOutputMode(sgrhtml.Classes))
+		}
+
+		if *document {
+			_, _ = e.stdout.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>")
+			_, _ = e.stdout.Write(sgrhtml.Convert([]byte(*title)))
+			_, _ = e.stdout.WriteString("</title>\n<style>\n")
+			_, _ = e.stdout.WriteString(sgrhtml.Stylesheet(options...))
+			_, _ = e.stdout.WriteString("pre { color: #e5e5e5; background: #000000; padding: 1em; }\n")
+			_, _ = e.stdout.WriteString("</style>\n</head>\n<body>\n<pre>")
+		}
+
+		w := sgrhtml.NewWriter(e.stdout, options...)
+		err := e.input(func(_ string, r io.Reader) error {
+			_, err := io.Copy(w, r)
+
+			return err
+		})
+		if err != nil {
+			return err
+		}
+
+		err = w.Close()
+		if err != nil {
+			return err
+		}
+
+		if *document {
+			_, err = e.stdout.WriteString("</pre>\n</body>\n</html>\n")
+		}
+
+		return err
+	}
+}
+
+func setupSVG(fs *flag.FlagSet) func(*env) error {
+	title := fs.String("title", "", "title of the window")
+	window := fs.Bool("window", true, "render the window title bar")
+	columns := fs.Int("columns", 0, "minimum number of columns")
+
+	return func(e *env) error {
+		data, err := e.readAll()
+		if err != nil {
+			return err
+		}
+
+		_, err = e.stdout.Write(sgrsvg.Render(data,
+			sgrsvg.WithWindow(*window, *title),
+			sgrsvg.WithColumns(*columns),
+		))
+
+		return err
+	}
+}
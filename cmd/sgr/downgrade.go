@@ -0,0 +1,53 @@
+package main
+
+import (
+	"flag"
+	"io"
+
+	"github.com/pamburus/go-ansi-esc/sgr"
+)
+
+// ---
+
+func setupDowngrade(fs *flag.FlagSet) func(*env) error {
+	profile := sgr.ColorProfile256
+	fs.TextVar(&profile, "profile", profile, "target color profile: truecolor, 256, 16 or none")
+
+	return func(e *env) error {
+		w := sgr.NewWriter(e.stdout, sgr.WithColorProfile(profile))
+
+		err := e.input(func(_ string, r io.Reader) error {
+			var interp sgr.Interpreter
+
+			return scanTokens(r, func(token []byte) error {
+				if !sgr.IsEscape(token) {
+					_, err := w.Write(token)
+
+					return err
+				}
+
+				if !sgr.IsSequence(token) {
+					if profile == sgr.ColorProfileNone {
+						return nil
+					}
+
+					_, err := w.Write(token)
+
+					return err
+				}
+
+				seq, _ := sgr.ParseSequence(token)
+				interp.Apply(seq)
+				w.Reset()
+				w.SetStyle(interp.Style())
+
+				return nil
+			})
+		})
+		if err != nil {
+			return err
+		}
+
+		return w.Flush()
+	}
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/pamburus/go-ansi-esc/sgr"
+)
+
+// ---
+
+func setupInspect(fs *flag.FlagSet) func(*env) error {
+	commands := fs.Bool("commands", false, "list each escape sequence with its position and commands instead of annotating the text")
+
+	return func(e *env) error {
+		if !*commands {
+			data, err := e.readAll()
+			if err != nil {
+				return err
+			}
+
+			_, err = e.stdout.WriteString(sgr.FormatDebug(data))
+
+			return err
+		}
+
+		return e.input(func(name string, r io.Reader) error {
+			line, col := 1, 1
+
+			return scanTokens(r, func(token []byte) error {
+				if !sgr.IsEscape(token) {
+					if i := bytes.LastIndexByte(token, '\n'); i >= 0 {
+						line += bytes.Count(token, []byte{'\n'})
+						col = sgr.Width(token[i+1:]) + 1
+					} else {
+						col += sgr.Width(token)
+					}
+
+					return nil
+				}
+
+				_, err := fmt.Fprintf(e.stdout, "%s:%d:%d: %s %s\n", name, line, col, strconv.QuoteToASCII(string(token)), describe(token))
+
+				return err
+			})
+		})
+	}
+}
+
+func describe(token []byte) string {
+	if !sgr.IsSequence(token) {
+		return "(not an SGR sequence)"
+	}
+
+	seq, err := sgr.ParseSequence(token)
+
+	names := make([]string, 0, len(seq)+1)
+	for _, c := range seq {
+		names = append(names, c.String())
+	}
+	if err != nil {
+		names = append(names, "(invalid)")
+	}
+
+	return strings.Join(names, " ")
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/pamburus/go-ansi-esc/sgr"
+)
+
+// ---
+
+func setupLint(fs *flag.FlagSet) func(*env) error {
+	perLine := fs.Bool("lines", true, "require styles to be closed at the end of each line, not only at the end of input")
+
+	return func(e *env) error {
+		issues := 0
+
+		report := func(name string, line int, format string, args ...any) error {
+			issues++
+			_, err := fmt.Fprintf(e.stdout, "%s:%d: %s\n", name, line, fmt.Sprintf(format, args...))
+
+			return err
+		}
+
+		err := e.input(func(name string, r io.Reader) error {
+			var interp sgr.Interpreter
+			line := 1
+
+			err := scanTokens(r, func(token []byte) error {
+				if sgr.IsEscape(token) {
+					if sgr.IsSequence(token) {
+						seq, err := sgr.ParseSequence(token)
+						interp.Apply(seq)
+						if err != nil {
+							return report(name, line, "invalid sequence %s", strconv.QuoteToASCII(string(token)))
+						}
+					}
+
+					return nil
+				}
+
+				for {
+					i := bytes.IndexByte(token, '\n')
+					if i < 0 {
+						return nil
+					}
+
+					if *perLine && interp.Style() != defaultStyle {
+						err := report(name, line, "style %q is not closed at the end of line", effective(interp.Style()))
+						if err != nil {
+							return err
+						}
+						interp.Reset()
+					}
+
+					line++
+					token = token[i+1:]
+				}
+			})
+			if err != nil {
+				return err
+			}
+
+			if interp.Style() != defaultStyle {
+				return report(name, line, "style %q is not closed at the end of input", effective(interp.Style()))
+			}
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		if issues != 0 {
+			_, _ = fmt.Fprintf(e.stdout, "%d issue(s) found\n", issues)
+
+			return errIssuesFound
+		}
+
+		return nil
+	}
+}
+
+// effective returns the style with terminal default colors replaced by zero colors
+// so that only the attributes differing from terminal defaults are shown.
+func effective(s sgr.Style) sgr.Style {
+	for _, c := range []*sgr.Color{&s.Foreground, &s.Background, &s.UnderlineColor} {
+		if c.IsDefaultColor() {
+			*c = 0
+		}
+	}
+
+	return s
+}
+
+// ---
+
+var defaultStyle = new(sgr.Interpreter).Style()
@@ -0,0 +1,166 @@
+// Command sgr provides tools for working with text containing CSI/SGR ANSI Escape Sequences, like colored CI logs.
+//
+// Usage:
+//
+//	sgr <command> [flags] [file...]
+//
+// Commands:
+//
+//	strip      remove all escape sequences
+//	inspect    show escape sequences in an annotated human-readable form
+//	html       convert to HTML
+//	svg        render to an SVG image looking like a terminal window
+//	downgrade  rewrite colors to fit the given color profile
+//	palette    print all basic and palette colors
+//	lint       report styles left unclosed at the end of lines and invalid sequences
+//
+// All commands except palette read the given files or the standard input if no files are given
+// and write the result to the standard output.
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// ---
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run runs the command with the given arguments and returns the exit code.
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "-help" || args[0] == "--help" || args[0] == "help" {
+		usage(stderr)
+
+		return 2
+	}
+
+	cmd, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(stderr, "sgr: unknown command %q\n", args[0])
+		usage(stderr)
+
+		return 2
+	}
+
+	fs := flag.NewFlagSet("sgr "+args[0], flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	exec := cmd.setup(fs)
+
+	err := fs.Parse(args[1:])
+	if err != nil {
+		return 2
+	}
+
+	env := &env{fs.Args(), stdin, bufio.NewWriter(stdout)}
+
+	err = exec(env)
+	if flushErr := env.stdout.Flush(); err == nil {
+		err = flushErr
+	}
+
+	switch {
+	case errors.Is(err, errIssuesFound):
+		return 1
+	case err != nil:
+		fmt.Fprintf(stderr, "sgr %s: %v\n", args[0], err)
+
+		return 1
+	default:
+		return 0
+	}
+}
+
+func usage(w io.Writer) {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintln(w, "usage: sgr <command> [flags] [file...]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "commands:")
+	for _, name := range names {
+		fmt.Fprintf(w, "  %-10s %s\n", name, commands[name].description)
+	}
+}
+
+// ---
+
+type command struct {
+	description string
+	setup       func(fs *flag.FlagSet) func(*env) error
+}
+
+var commands = map[string]command{
+	"strip":     {"remove all escape sequences", setupStrip},
+	"inspect":   {"show escape sequences in an annotated human-readable form", setupInspect},
+	"html":      {"convert to HTML", setupHTML},
+	"svg":       {"render to an SVG image looking like a terminal window", setupSVG},
+	"downgrade": {"rewrite colors to fit the given color profile", setupDowngrade},
+	"palette":   {"print all basic and palette colors", setupPalette},
+	"lint":      {"report styles left unclosed at the end of lines and invalid sequences", setupLint},
+}
+
+// ---
+
+type env struct {
+	args   []string
+	stdin  io.Reader
+	stdout *bufio.Writer
+}
+
+// input calls f for each input, which is each file given in the arguments or the standard input.
+func (e *env) input(f func(name string, r io.Reader) error) error {
+	if len(e.args) == 0 {
+		return f("-", e.stdin)
+	}
+
+	for _, name := range e.args {
+		err := e.file(name, f)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// readAll reads all inputs concatenated.
+func (e *env) readAll() ([]byte, error) {
+	var sb strings.Builder
+	err := e.input(func(_ string, r io.Reader) error {
+		_, err := io.Copy(&sb, r)
+
+		return err
+	})
+
+	return []byte(sb.String()), err
+}
+
+func (e *env) file(name string, f func(name string, r io.Reader) error) error {
+	if name == "-" {
+		return f(name, e.stdin)
+	}
+
+	file, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return f(name, file)
+}
+
+// ---
+
+var errIssuesFound = errors.New("issues found")
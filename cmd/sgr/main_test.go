@@ -0,0 +1,136 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	. "github.com/pamburus/go-tst/tst"
+)
+
+func TestRun(tt *testing.T) {
+	t := New(tt)
+
+	exec := func(stdin string, args ...string) (int, string, string) {
+		var stdout, stderr strings.Builder
+		code := run(args, strings.NewReader(stdin), &stdout, &stderr)
+
+		return code, stdout.String(), stderr.String()
+	}
+
+	t.Run("Usage", func(t Test) {
+		code, stdout, stderr := exec("")
+		t.Expect(code).ToEqual(2)
+		t.Expect(stdout).ToEqual("")
+		t.Expect(strings.Contains(stderr, "usage: sgr <command>")).ToBeTrue()
+		t.Expect(strings.Contains(stderr, "  downgrade  rewrite colors")).ToBeTrue()
+
+		code, _, stderr = exec("", "unknown")
+		t.Expect(code).ToEqual(2)
+		t.Expect(strings.HasPrefix(stderr, `sgr: unknown command "unknown"`)).ToBeTrue()
+
+		code, _, _ = exec("", "strip", "-unknown")
+		t.Expect(code).ToEqual(2)
+	})
+
+	t.Run("Strip", func(t Test) {
+		code, stdout, _ := exec("\x1b[1;31mhello\x1b[0m \x1b]0;title\x07world\n", "strip")
+		t.Expect(code).ToEqual(0)
+		t.Expect(stdout).ToEqual("hello world\n")
+	})
+
+	t.Run("Files", func(t Test) {
+		dir := t.TempDir()
+		a := filepath.Join(dir, "a.txt")
+		b := filepath.Join(dir, "b.txt")
+		t.Expect(os.WriteFile(a, []byte("\x1b[1ma\x1b[m\n"), 0o600)).ToSucceed()
+		t.Expect(os.WriteFile(b, []byte("\x1b[2mb\x1b[m\n"), 0o600)).ToSucceed()
+
+		code, stdout, _ := exec("-\n", "strip", a, "-", b)
+		t.Expect(code).ToEqual(0)
+		t.Expect(stdout).ToEqual("a\n-\nb\n")
+
+		code, _, stderr := exec("", "strip", filepath.Join(dir, "missing.txt"))
+		t.Expect(code).ToEqual(1)
+		t.Expect(strings.HasPrefix(stderr, "sgr strip: open ")).ToBeTrue()
+	})
+
+	t.Run("Inspect", func(t Test) {
+		code, stdout, _ := exec("\x1b[1;31mhi\x1b[0m\n", "inspect")
+		t.Expect(code).ToEqual(0)
+		t.Expect(stdout).ToEqual("⟨+Bold fg:Red⟩hi⟨reset⟩\n")
+
+		code, stdout, _ = exec("a\n\x1b[1;31mhi\x1b[0m", "inspect", "-commands")
+		t.Expect(code).ToEqual(0)
+		t.Expect(stdout).ToEqual("-:2:1: \"\\x1b[1;31m\" SetBold SetForegroundColorRed\n-:2:3: \"\\x1b[0m\" ResetAll\n")
+	})
+
+	t.Run("HTML", func(t Test) {
+		code, stdout, _ := exec("\x1b[1mhi\x1b[0m", "html")
+		t.Expect(code).ToEqual(0)
+		t.Expect(strings.Contains(stdout, "hi</span>")).ToBeTrue()
+		t.Expect(strings.Contains(stdout, "<html>")).ToBeFalse()
+
+		code, stdout, _ = exec("\x1b[1mhi\x1b[0m", "html", "-document", "-title", "<log>")
+		t.Expect(code).ToEqual(0)
+		t.Expect(strings.HasPrefix(stdout, "<!DOCTYPE html>")).ToBeTrue()
+		t.Expect(strings.Contains(stdout, "<title>&lt;log&gt;</title>")).ToBeTrue()
+	})
+
+	t.Run("SVG", func(t Test) {
+		code, stdout, _ := exec("\x1b[31mhi\x1b[0m", "svg", "-title", "demo")
+		t.Expect(code).ToEqual(0)
+		t.Expect(strings.HasPrefix(stdout, "<svg ")).ToBeTrue()
+		t.Expect(strings.Contains(stdout, ">demo</text>")).ToBeTrue()
+	})
+
+	t.Run("Downgrade", func(t Test) {
+		input := "\x1b[38;2;255;0;0mred\x1b[0m \x1b[1;38;5;196mbold\x1b[m\x1b]0;title\x07\n"
+
+		code, stdout, _ := exec(input, "downgrade")
+		t.Expect(code).ToEqual(0)
+		t.Expect(stdout).ToEqual("\x1b[38;5;196mred\x1b[0m \x1b[38;5;196;1mbold\x1b[0m\x1b]0;title\x07\n")
+
+		code, stdout, _ = exec(input, "downgrade", "-profile", "16")
+		t.Expect(code).ToEqual(0)
+		t.Expect(stdout).ToEqual("\x1b[91mred\x1b[0m \x1b[91;1mbold\x1b[0m\x1b]0;title\x07\n")
+
+		code, stdout, _ = exec(input, "downgrade", "-profile", "none")
+		t.Expect(code).ToEqual(0)
+		t.Expect(stdout).ToEqual("red bold\n")
+
+		code, _, _ = exec(input, "downgrade", "-profile", "bad")
+		t.Expect(code).ToEqual(2)
+	})
+
+	t.Run("Palette", func(t Test) {
+		code, stdout, _ := exec("", "palette")
+		t.Expect(code).ToEqual(0)
+		t.Expect(strings.Contains(stdout, "BrightMagenta")).ToBeTrue()
+		t.Expect(strings.Contains(stdout, "\x1b[48;5;255m 255 ")).ToBeTrue()
+
+		code, stdout, _ = exec("", "palette", "-profile", "none")
+		t.Expect(code).ToEqual(0)
+		t.Expect(strings.Contains(stdout, "\x1b")).ToBeFalse()
+	})
+
+	t.Run("Lint", func(t Test) {
+		code, stdout, _ := exec("\x1b[1mok\x1b[0m\n", "lint")
+		t.Expect(code).ToEqual(0)
+		t.Expect(stdout).ToEqual("")
+
+		code, stdout, stderr := exec("a\x1b[1mb\nc\x1b[0m\n\x1b[31mx", "lint")
+		t.Expect(code).ToEqual(1)
+		t.Expect(stderr).ToEqual("")
+		t.Expect(stdout).ToEqual(
+			"-:1: style \"bold\" is not closed at the end of line\n" +
+				"-:3: style \"red\" is not closed at the end of input\n" +
+				"2 issue(s) found\n",
+		)
+
+		code, stdout, _ = exec("a\x1b[1mb\nc\x1b[0m\n", "lint", "-lines=false")
+		t.Expect(code).ToEqual(0)
+		t.Expect(stdout).ToEqual("")
+	})
+}
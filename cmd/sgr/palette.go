@@ -0,0 +1,68 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/pamburus/go-ansi-esc/sgr"
+)
+
+// ---
+
+func setupPalette(fs *flag.FlagSet) func(*env) error {
+	profile := sgr.ColorProfileTrueColor
+	fs.TextVar(&profile, "profile", profile, "color profile used to render the colors: truecolor, 256, 16 or none")
+
+	return func(e *env) error {
+		w := sgr.NewWriter(e.stdout, sgr.WithColorProfile(profile))
+
+		sample := func(color sgr.Color, text string) {
+			w.PushBackgroundColor(color)
+			w.PushForegroundColor(contrast(color))
+			_, _ = w.WriteString(text)
+			w.PopForegroundColor()
+			w.PopBackgroundColor()
+		}
+
+		_, _ = w.WriteString("Basic colors:\n")
+		for _, brightness := range []sgr.Brightness{sgr.Normal, sgr.Bright} {
+			for c := sgr.Black; c <= sgr.White; c++ {
+				color := c.WithBrightness(brightness)
+				sample(color.Color(), fmt.Sprintf(" %-13s ", color))
+				if c == sgr.Yellow || c == sgr.White {
+					_, _ = w.WriteString("\n")
+				}
+			}
+		}
+
+		_, _ = w.WriteString("\nPalette colors:\n")
+		for i := 0; i < 256; i++ {
+			sample(sgr.PaletteColor(i).Color(), fmt.Sprintf(" %3d ", i))
+
+			switch {
+			case i == 15, i == 231, i == 255:
+				_, _ = w.WriteString("\n\n")
+			case i > 15 && i < 232 && (i-16)%6 == 5:
+				if (i-16)%12 == 11 {
+					_, _ = w.WriteString("\n")
+				} else {
+					_, _ = w.WriteString("  ")
+				}
+			case i == 7, i == 243:
+				_, _ = w.WriteString("\n")
+			}
+		}
+
+		return w.Flush()
+	}
+}
+
+// contrast returns black or white color depending on which one is better readable on the given background color.
+func contrast(background sgr.Color) sgr.Color {
+	rgb, _ := sgr.DefaultPalette.Resolve(background)
+	if rgb.IsDark() {
+		return sgr.BrightWhite.Color()
+	}
+
+	return sgr.Black.Color()
+}
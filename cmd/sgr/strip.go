@@ -0,0 +1,45 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"io"
+
+	"github.com/pamburus/go-ansi-esc/sgr"
+)
+
+// ---
+
+func setupStrip(*flag.FlagSet) func(*env) error {
+	return func(e *env) error {
+		return e.input(func(_ string, r io.Reader) error {
+			return scanTokens(r, func(token []byte) error {
+				if sgr.IsEscape(token) {
+					return nil
+				}
+
+				_, err := e.stdout.Write(token)
+
+				return err
+			})
+		})
+	}
+}
+
+// scanTokens calls f for each token of r split using sgr.ScanTokens.
+func scanTokens(r io.Reader, f func(token []byte) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxTokenSize)
+	scanner.Split(sgr.ScanTokens)
+
+	for scanner.Scan() {
+		err := f(scanner.Bytes())
+		if err != nil {
+			return err
+		}
+	}
+
+	return scanner.Err()
+}
+
+const maxTokenSize = 16 * 1024 * 1024
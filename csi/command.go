@@ -0,0 +1,325 @@
+package csi
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// ---
+
+// CursorUp returns CUU command that moves the cursor n lines up.
+// It returns zero command if n is not positive because terminals treat zero count as 1.
+func CursorUp(n int) Command {
+	return count(FuncCursorUp, n)
+}
+
+// CursorDown returns CUD command that moves the cursor n lines down.
+// It returns zero command if n is not positive because terminals treat zero count as 1.
+func CursorDown(n int) Command {
+	return count(FuncCursorDown, n)
+}
+
+// CursorForward returns CUF command that moves the cursor n columns right.
+// It returns zero command if n is not positive because terminals treat zero count as 1.
+func CursorForward(n int) Command {
+	return count(FuncCursorForward, n)
+}
+
+// CursorBack returns CUB command that moves the cursor n columns left.
+// It returns zero command if n is not positive because terminals treat zero count as 1.
+func CursorBack(n int) Command {
+	return count(FuncCursorBack, n)
+}
+
+// CursorPosition returns CUP command that moves the cursor to the given 1-based row and column.
+// Values less than 1 are treated as 1.
+func CursorPosition(row, col int) Command {
+	return position(FuncCursorPosition, row, col)
+}
+
+// HorizontalVerticalPosition returns HVP command that moves the cursor to the given 1-based row and column.
+// Values less than 1 are treated as 1.
+// It has the same effect as CursorPosition in all common terminals.
+func HorizontalVerticalPosition(row, col int) Command {
+	return position(FuncHorizontalVerticalPosition, row, col)
+}
+
+// CursorHorizontalAbsolute returns CHA command that moves the cursor to the given 1-based column of the current line.
+// Values less than 1 are treated as 1.
+func CursorHorizontalAbsolute(col int) Command {
+	return newCommand(FuncCursorHorizontalAbsolute, clamp(col, 1), 0, 1)
+}
+
+// EraseInDisplay returns ED command that erases the given part of the display.
+func EraseInDisplay(mode EraseMode) Command {
+	return newCommand(FuncEraseInDisplay, int(mode), 0, 1)
+}
+
+// EraseInLine returns EL command that erases the given part of the current line.
+// EraseSaved is not applicable to lines.
+func EraseInLine(mode EraseMode) Command {
+	return newCommand(FuncEraseInLine, int(mode), 0, 1)
+}
+
+// EraseCharacters returns ECH command that erases n characters starting at the cursor position without moving the cursor.
+// It returns zero command if n is not positive because terminals treat zero count as 1.
+func EraseCharacters(n int) Command {
+	return count(FuncEraseCharacters, n)
+}
+
+// ScrollUp returns SU command that scrolls the contents of the scrolling region n lines up.
+// It returns zero command if n is not positive because terminals treat zero count as 1.
+func ScrollUp(n int) Command {
+	return count(FuncScrollUp, n)
+}
+
+// ScrollDown returns SD command that scrolls the contents of the scrolling region n lines down.
+// It returns zero command if n is not positive because terminals treat zero count as 1.
+func ScrollDown(n int) Command {
+	return count(FuncScrollDown, n)
+}
+
+// SetScrollingRegion returns DECSTBM command that sets the scrolling region to the given 1-based lines inclusively.
+// Values less than 1 are treated as 1.
+// Use ResetScrollingRegion to restore the scrolling region to the whole screen.
+func SetScrollingRegion(top, bottom int) Command {
+	return position(FuncSetScrollingRegion, top, bottom)
+}
+
+// InsertLines returns IL command that inserts n blank lines at the cursor line.
+// It returns zero command if n is not positive because terminals treat zero count as 1.
+func InsertLines(n int) Command {
+	return count(FuncInsertLines, n)
+}
+
+// DeleteLines returns DL command that deletes n lines starting at the cursor line.
+// It returns zero command if n is not positive because terminals treat zero count as 1.
+func DeleteLines(n int) Command {
+	return count(FuncDeleteLines, n)
+}
+
+// InsertCharacters returns ICH command that inserts n blank characters at the cursor position.
+// It returns zero command if n is not positive because terminals treat zero count as 1.
+func InsertCharacters(n int) Command {
+	return count(FuncInsertCharacters, n)
+}
+
+// DeleteCharacters returns DCH command that deletes n characters starting at the cursor position.
+// It returns zero command if n is not positive because terminals treat zero count as 1.
+func DeleteCharacters(n int) Command {
+	return count(FuncDeleteCharacters, n)
+}
+
+// ---
+
+// ResetScrollingRegion is DECSTBM command without parameters that restores the scrolling region to the whole screen.
+var ResetScrollingRegion = newCommand(FuncSetScrollingRegion, 0, 0, 0)
+
+// ---
+
+// Command is a single CSI control function with its parameters.
+// Zero value is an empty command that renders to nothing.
+type Command uint64
+
+// String returns textual description of c that can be used for debugging or logging purposes.
+func (c Command) String() string {
+	switch {
+	case c.IsZero():
+		return ""
+	case c == ResetScrollingRegion:
+		return "ResetScrollingRegion"
+	}
+
+	name := functionNames[c.Function()].name
+	switch c.Function() {
+	case FuncEraseInDisplay, FuncEraseInLine:
+		return fmt.Sprintf("%s(%s)", name, EraseMode(c.param(0)))
+	case FuncSetPrivateMode, FuncResetPrivateMode, FuncSavePrivateMode, FuncRestorePrivateMode:
+		return fmt.Sprintf("%s(%s)", name, PrivateMode(c.param(0)))
+	}
+
+	if c.paramCount() == 2 {
+		return fmt.Sprintf("%s(%d,%d)", name, c.param(0), c.param(1))
+	}
+
+	return fmt.Sprintf("%s(%d)", name, c.param(0))
+}
+
+// IsZero returns true if command is zero-initialized.
+// Zero commands are excluded from sequence during its rendition.
+func (c Command) IsZero() bool {
+	return c == 0
+}
+
+// Function returns the control function of the command leaving aside its parameters.
+func (c Command) Function() Function {
+	return Function(c & 0xFFFF)
+}
+
+// Render produces binary string corresponding to the command starting with "\x1b[" (ESC/CSI)
+// and ending with the final byte of the control function.
+func (c Command) Render(buf []byte) []byte {
+	if c.IsZero() {
+		return buf
+	}
+
+	buf = append(buf, seqBegin...)
+	if marker := c.Function().marker(); marker != 0 {
+		buf = append(buf, marker)
+	}
+	for i := 0; i != c.paramCount(); i++ {
+		if i != 0 {
+			buf = append(buf, seqNext)
+		}
+		buf = strconv.AppendUint(buf, uint64(c.param(i)), 10)
+	}
+
+	return append(buf, c.Function().final())
+}
+
+// Bytes returns binary string with the serialized command, the same as produces by Render but collected into a new byte slice.
+func (c Command) Bytes() []byte {
+	return c.Render(make([]byte, 0, 16))
+}
+
+func (c Command) paramCount() int {
+	return int((c >> paramCountShift) & 0xFF)
+}
+
+func (c Command) param(i int) uint16 {
+	return uint16(c >> (paramShift + i*16))
+}
+
+// ---
+
+// Function is a CSI control function identified by its final byte and optional private parameter marker like '?'.
+type Function uint16
+
+// Complete set of supported Function values.
+const (
+	FuncCursorUp                   Function = 'A'
+	FuncCursorDown                 Function = 'B'
+	FuncCursorForward              Function = 'C'
+	FuncCursorBack                 Function = 'D'
+	FuncCursorHorizontalAbsolute   Function = 'G'
+	FuncCursorPosition             Function = 'H'
+	FuncEraseInDisplay             Function = 'J'
+	FuncEraseInLine                Function = 'K'
+	FuncInsertLines                Function = 'L'
+	FuncDeleteLines                Function = 'M'
+	FuncDeleteCharacters           Function = 'P'
+	FuncScrollUp                   Function = 'S'
+	FuncScrollDown                 Function = 'T'
+	FuncEraseCharacters            Function = 'X'
+	FuncInsertCharacters           Function = '@'
+	FuncHorizontalVerticalPosition Function = 'f'
+	FuncSetScrollingRegion         Function = 'r'
+	FuncSetPrivateMode             Function = '?'<<8 | 'h'
+	FuncResetPrivateMode           Function = '?'<<8 | 'l'
+	FuncSavePrivateMode            Function = '?'<<8 | 's'
+	FuncRestorePrivateMode         Function = '?'<<8 | 'r'
+)
+
+// String returns the mnemonic of the control function, like "CUU" or "DECSTBM".
+func (f Function) String() string {
+	if info, ok := functionNames[f]; ok {
+		return info.mnemonic
+	}
+
+	if f.marker() != 0 {
+		return fmt.Sprintf("<!%q>", string([]byte{f.marker(), f.final()}))
+	}
+
+	return fmt.Sprintf("<!%q>", f.final())
+}
+
+func (f Function) final() byte {
+	return byte(f)
+}
+
+func (f Function) marker() byte {
+	return byte(f >> 8)
+}
+
+// ---
+
+// EraseMode specifies which part of the display or line is erased by EraseInDisplay and EraseInLine.
+type EraseMode uint8
+
+// Complete set of supported EraseMode values.
+const (
+	EraseToEnd       EraseMode = 0 // From the cursor to the end, inclusively.
+	EraseToBeginning EraseMode = 1 // From the beginning to the cursor, inclusively.
+	EraseAll         EraseMode = 2 // Everything.
+	EraseSaved       EraseMode = 3 // Lines saved in the scrollback buffer, display only.
+)
+
+// String returns textual description of m that can be used for debugging or logging purposes.
+func (m EraseMode) String() string {
+	switch m {
+	case EraseToEnd:
+		return "EraseToEnd"
+	case EraseToBeginning:
+		return "EraseToBeginning"
+	case EraseAll:
+		return "EraseAll"
+	case EraseSaved:
+		return "EraseSaved"
+	default:
+		return fmt.Sprintf("<!%d>", uint8(m))
+	}
+}
+
+// ---
+
+func newCommand(f Function, p0, p1, n int) Command {
+	return Command(f) | Command(n)<<paramCountShift | Command(uint16(p0))<<paramShift | Command(uint16(p1))<<(paramShift+16)
+}
+
+func count(f Function, n int) Command {
+	if n <= 0 {
+		return 0
+	}
+
+	return newCommand(f, clamp(n, 1), 0, 1)
+}
+
+func position(f Function, a, b int) Command {
+	return newCommand(f, clamp(a, 1), clamp(b, 1), 2)
+}
+
+func clamp(value, lo int) int {
+	return min(max(value, lo), maxParam)
+}
+
+// ---
+
+var functionNames = map[Function]struct{ mnemonic, name string }{
+	FuncCursorUp:                   {"CUU", "CursorUp"},
+	FuncCursorDown:                 {"CUD", "CursorDown"},
+	FuncCursorForward:              {"CUF", "CursorForward"},
+	FuncCursorBack:                 {"CUB", "CursorBack"},
+	FuncCursorHorizontalAbsolute:   {"CHA", "CursorHorizontalAbsolute"},
+	FuncCursorPosition:             {"CUP", "CursorPosition"},
+	FuncEraseInDisplay:             {"ED", "EraseInDisplay"},
+	FuncEraseInLine:                {"EL", "EraseInLine"},
+	FuncInsertLines:                {"IL", "InsertLines"},
+	FuncDeleteLines:                {"DL", "DeleteLines"},
+	FuncDeleteCharacters:           {"DCH", "DeleteCharacters"},
+	FuncScrollUp:                   {"SU", "ScrollUp"},
+	FuncScrollDown:                 {"SD", "ScrollDown"},
+	FuncEraseCharacters:            {"ECH", "EraseCharacters"},
+	FuncInsertCharacters:           {"ICH", "InsertCharacters"},
+	FuncHorizontalVerticalPosition: {"HVP", "HorizontalVerticalPosition"},
+	FuncSetScrollingRegion:         {"DECSTBM", "SetScrollingRegion"},
+	FuncSetPrivateMode:             {"DECSET", "SetPrivateMode"},
+	FuncResetPrivateMode:           {"DECRST", "ResetPrivateMode"},
+	FuncSavePrivateMode:            {"XTSAVE", "SavePrivateMode"},
+	FuncRestorePrivateMode:         {"XTRESTORE", "RestorePrivateMode"},
+}
+
+const (
+	paramCountShift = 16
+	paramShift      = 24
+	maxParam        = 0xFFFF
+)
@@ -0,0 +1,78 @@
+package csi_test
+
+import (
+	"testing"
+
+	. "github.com/pamburus/go-tst/tst"
+
+	"github.com/pamburus/go-ansi-esc/csi"
+)
+
+func TestCommand(tt *testing.T) {
+	t := New(tt)
+
+	t.Run("Render", func(t Test) {
+		for _, tc := range []struct {
+			command csi.Command
+			want    string
+		}{
+			{csi.CursorUp(3), "\x1b[3A"},
+			{csi.CursorDown(1), "\x1b[1B"},
+			{csi.CursorForward(12), "\x1b[12C"},
+			{csi.CursorBack(65535), "\x1b[65535D"},
+			{csi.CursorPosition(2, 40), "\x1b[2;40H"},
+			{csi.HorizontalVerticalPosition(5, 1), "\x1b[5;1f"},
+			{csi.CursorHorizontalAbsolute(7), "\x1b[7G"},
+			{csi.EraseInDisplay(csi.EraseAll), "\x1b[2J"},
+			{csi.EraseInDisplay(csi.EraseSaved), "\x1b[3J"},
+			{csi.EraseInLine(csi.EraseToEnd), "\x1b[0K"},
+			{csi.EraseInLine(csi.EraseToBeginning), "\x1b[1K"},
+			{csi.EraseCharacters(4), "\x1b[4X"},
+			{csi.ScrollUp(2), "\x1b[2S"},
+			{csi.ScrollDown(3), "\x1b[3T"},
+			{csi.SetScrollingRegion(1, 20), "\x1b[1;20r"},
+			{csi.ResetScrollingRegion, "\x1b[r"},
+			{csi.InsertLines(2), "\x1b[2L"},
+			{csi.DeleteLines(3), "\x1b[3M"},
+			{csi.InsertCharacters(4), "\x1b[4@"},
+			{csi.DeleteCharacters(5), "\x1b[5P"},
+		} {
+			t.Expect(string(tc.command.Bytes())).ToEqual(tc.want)
+			t.Expect(string(tc.command.Render([]byte("x")))).ToEqual("x" + tc.want)
+		}
+	})
+
+	t.Run("Clamp", func(t Test) {
+		t.Expect(csi.CursorUp(0).IsZero()).ToBeTrue()
+		t.Expect(csi.CursorBack(-3).IsZero()).ToBeTrue()
+		t.Expect(csi.DeleteLines(0).Bytes()).ToEqual([]byte{})
+		t.Expect(string(csi.CursorUp(100000).Bytes())).ToEqual("\x1b[65535A")
+		t.Expect(string(csi.CursorPosition(0, -1).Bytes())).ToEqual("\x1b[1;1H")
+		t.Expect(string(csi.CursorHorizontalAbsolute(0).Bytes())).ToEqual("\x1b[1G")
+		t.Expect(string(csi.SetScrollingRegion(-5, 1<<20).Bytes())).ToEqual("\x1b[1;65535r")
+	})
+
+	t.Run("Function", func(t Test) {
+		t.Expect(csi.CursorUp(2).Function()).ToEqual(csi.FuncCursorUp)
+		t.Expect(csi.ResetScrollingRegion.Function()).ToEqual(csi.FuncSetScrollingRegion)
+		t.Expect(csi.FuncCursorPosition.String()).ToEqual("CUP")
+		t.Expect(csi.FuncSetScrollingRegion.String()).ToEqual("DECSTBM")
+		t.Expect(csi.Function('z').String()).ToEqual(`<!'z'>`)
+	})
+
+	t.Run("String", func(t Test) {
+		t.Expect(csi.Command(0).String()).ToEqual("")
+		t.Expect(csi.CursorUp(3).String()).ToEqual("CursorUp(3)")
+		t.Expect(csi.CursorPosition(2, 5).String()).ToEqual("CursorPosition(2,5)")
+		t.Expect(csi.EraseInLine(csi.EraseAll).String()).ToEqual("EraseInLine(EraseAll)")
+		t.Expect(csi.EraseInDisplay(csi.EraseMode(7)).String()).ToEqual("EraseInDisplay(<!7>)")
+		t.Expect(csi.ResetScrollingRegion.String()).ToEqual("ResetScrollingRegion")
+		t.Expect(csi.SetScrollingRegion(2, 10).String()).ToEqual("SetScrollingRegion(2,10)")
+	})
+
+	t.Run("EraseMode", func(t Test) {
+		t.Expect(csi.EraseToEnd.String()).ToEqual("EraseToEnd")
+		t.Expect(csi.EraseToBeginning.String()).ToEqual("EraseToBeginning")
+		t.Expect(csi.EraseSaved.String()).ToEqual("EraseSaved")
+	})
+}
@@ -0,0 +1,87 @@
+package csi
+
+import (
+	"fmt"
+)
+
+// ---
+
+// PrivateMode is a DEC private mode that can be set or reset using DECSET and DECRST commands.
+type PrivateMode uint16
+
+// Commonly supported PrivateMode values.
+const (
+	ModeAutoWrap                 PrivateMode = 7    // Wrap text at the right margin.
+	ModeCursorVisible            PrivateMode = 25   // Show the cursor.
+	ModeMouseTracking            PrivateMode = 1000 // Report mouse button presses and releases.
+	ModeMouseButtonEventTracking PrivateMode = 1002 // Report mouse button presses, releases and motion with a button pressed.
+	ModeMouseAnyEventTracking    PrivateMode = 1003 // Report mouse button presses, releases and any motion.
+	ModeMouseSGRExtended         PrivateMode = 1006 // Report mouse events using SGR extended format.
+	ModeAlternateScreen          PrivateMode = 1049 // Save the cursor and switch to the alternate screen buffer.
+	ModeBracketedPaste           PrivateMode = 2004 // Surround pasted text with special sequences.
+	ModeSynchronizedOutput       PrivateMode = 2026 // Postpone screen updates until the mode is reset.
+)
+
+// Set returns DECSET command that enables the mode.
+func (m PrivateMode) Set() Command {
+	return newCommand(FuncSetPrivateMode, int(m), 0, 1)
+}
+
+// Reset returns DECRST command that disables the mode.
+func (m PrivateMode) Reset() Command {
+	return newCommand(FuncResetPrivateMode, int(m), 0, 1)
+}
+
+// Save returns XTSAVE command that makes terminal save the current state of the mode.
+// It is supported by xterm and some other terminals but not universally.
+func (m PrivateMode) Save() Command {
+	return newCommand(FuncSavePrivateMode, int(m), 0, 1)
+}
+
+// Restore returns XTRESTORE command that makes terminal restore the state of the mode saved by Save.
+// It is supported by xterm and some other terminals but not universally.
+func (m PrivateMode) Restore() Command {
+	return newCommand(FuncRestorePrivateMode, int(m), 0, 1)
+}
+
+// Switch returns Set command if enabled is true and Reset command otherwise.
+func (m PrivateMode) Switch(enabled bool) Command {
+	if enabled {
+		return m.Set()
+	}
+
+	return m.Reset()
+}
+
+// Default returns the state of the mode that terminals have by default.
+func (m PrivateMode) Default() bool {
+	switch m {
+	case ModeAutoWrap, ModeCursorVisible:
+		return true
+	default:
+		return false
+	}
+}
+
+// String returns textual description of m that can be used for debugging or logging purposes.
+func (m PrivateMode) String() string {
+	if name, ok := privateModeNames[m]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("?%d", uint16(m))
+}
+
+// ---
+
+var privateModeNames = map[PrivateMode]string{
+	ModeAutoWrap:                 "AutoWrap",
+	ModeCursorVisible:            "CursorVisible",
+	ModeMouseTracking:            "MouseTracking",
+	ModeMouseButtonEventTracking: "MouseButtonEventTracking",
+	ModeMouseAnyEventTracking:    "MouseAnyEventTracking",
+	ModeMouseSGRExtended:         "MouseSGRExtended",
+	ModeAlternateScreen:          "AlternateScreen",
+	ModeBracketedPaste:           "BracketedPaste",
+	ModeSynchronizedOutput:       "SynchronizedOutput",
+}
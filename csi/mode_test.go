@@ -0,0 +1,44 @@
+package csi_test
+
+import (
+	"testing"
+
+	. "github.com/pamburus/go-tst/tst"
+
+	"github.com/pamburus/go-ansi-esc/csi"
+)
+
+func TestPrivateMode(tt *testing.T) {
+	t := New(tt)
+
+	t.Run("Render", func(t Test) {
+		t.Expect(string(csi.ModeCursorVisible.Set().Bytes())).ToEqual("\x1b[?25h")
+		t.Expect(string(csi.ModeCursorVisible.Reset().Bytes())).ToEqual("\x1b[?25l")
+		t.Expect(string(csi.ModeAlternateScreen.Save().Bytes())).ToEqual("\x1b[?1049s")
+		t.Expect(string(csi.ModeAlternateScreen.Restore().Bytes())).ToEqual("\x1b[?1049r")
+		t.Expect(string(csi.ModeBracketedPaste.Switch(true).Bytes())).ToEqual("\x1b[?2004h")
+		t.Expect(string(csi.ModeSynchronizedOutput.Switch(false).Bytes())).ToEqual("\x1b[?2026l")
+		t.Expect(string(csi.Sequence{
+			csi.ModeMouseTracking.Set(),
+			csi.ModeMouseButtonEventTracking.Set(),
+			csi.ModeMouseAnyEventTracking.Set(),
+			csi.ModeMouseSGRExtended.Set(),
+			csi.ModeAutoWrap.Reset(),
+		}.Bytes())).ToEqual("\x1b[?1000h\x1b[?1002h\x1b[?1003h\x1b[?1006h\x1b[?7l")
+	})
+
+	t.Run("Default", func(t Test) {
+		t.Expect(csi.ModeAutoWrap.Default()).ToBeTrue()
+		t.Expect(csi.ModeCursorVisible.Default()).ToBeTrue()
+		t.Expect(csi.ModeAlternateScreen.Default()).ToBeFalse()
+		t.Expect(csi.PrivateMode(12).Default()).ToBeFalse()
+	})
+
+	t.Run("String", func(t Test) {
+		t.Expect(csi.ModeSynchronizedOutput.String()).ToEqual("SynchronizedOutput")
+		t.Expect(csi.PrivateMode(12).String()).ToEqual("?12")
+		t.Expect(csi.ModeCursorVisible.Reset().String()).ToEqual("ResetPrivateMode(CursorVisible)")
+		t.Expect(csi.ModeAlternateScreen.Save().Function().String()).ToEqual("XTSAVE")
+		t.Expect(csi.Function('?'<<8 | 'z').String()).ToEqual(`<!"?z">`)
+	})
+}
@@ -0,0 +1,121 @@
+package csi
+
+import (
+	"io"
+)
+
+// ---
+
+// NewModeStack returns a new ModeStack writing commands to the given target.
+// All modes are assumed to be in their default state initially, see PrivateMode.Default.
+func NewModeStack(target io.Writer) *ModeStack {
+	return &ModeStack{target: target}
+}
+
+// ModeStack keeps track of DEC private modes changed by the application and restores them back.
+//
+// Each change is pushed to the stack and can be reverted by Pop.
+// Restore reverts all changes still in the stack and is intended to be deferred
+// right after creation of the stack, so that the terminal is restored on return and on panic:
+//
+//	modes := csi.NewModeStack(os.Stdout)
+//	defer modes.Restore()
+//
+//	modes.Push(csi.ModeAlternateScreen, true)
+//	modes.Push(csi.ModeCursorVisible, false)
+//
+// Note that os.Exit does not run deferred calls, so Restore must be called explicitly before it.
+// ModeStack is not safe for concurrent use.
+type ModeStack struct {
+	target io.Writer
+	state  map[PrivateMode]bool
+	stack  []modeChange
+	buf    []byte
+}
+
+// Enabled returns current state of the mode as known to the stack.
+func (s *ModeStack) Enabled(mode PrivateMode) bool {
+	if enabled, ok := s.state[mode]; ok {
+		return enabled
+	}
+
+	return mode.Default()
+}
+
+// Len returns the number of changes in the stack.
+func (s *ModeStack) Len() int {
+	return len(s.stack)
+}
+
+// Push switches the mode to the given state and pushes the change to the stack.
+// Nothing is written if the mode is already in the requested state, but the change is still pushed
+// so that each Push can be paired with Pop.
+func (s *ModeStack) Push(mode PrivateMode, enabled bool) error {
+	s.stack = append(s.stack, modeChange{mode, s.Enabled(mode)})
+
+	return s.change(mode, enabled)
+}
+
+// Pop reverts the last change pushed to the stack.
+// It does nothing if the stack is empty.
+func (s *ModeStack) Pop() error {
+	if len(s.stack) == 0 {
+		return nil
+	}
+
+	last := s.stack[len(s.stack)-1]
+	s.stack = s.stack[:len(s.stack)-1]
+
+	return s.change(last.mode, last.enabled)
+}
+
+// Restore reverts all changes in the stack in reverse order writing all the needed commands at once.
+// It is intended to be deferred and is safe to call multiple times.
+func (s *ModeStack) Restore() error {
+	s.buf = s.buf[:0]
+	for len(s.stack) != 0 {
+		last := s.stack[len(s.stack)-1]
+		s.stack = s.stack[:len(s.stack)-1]
+		s.buf = s.update(s.buf, last.mode, last.enabled)
+	}
+
+	return s.flush()
+}
+
+// ---
+
+func (s *ModeStack) change(mode PrivateMode, enabled bool) error {
+	s.buf = s.update(s.buf[:0], mode, enabled)
+
+	return s.flush()
+}
+
+func (s *ModeStack) update(buf []byte, mode PrivateMode, enabled bool) []byte {
+	if s.Enabled(mode) == enabled {
+		return buf
+	}
+
+	if s.state == nil {
+		s.state = make(map[PrivateMode]bool)
+	}
+	s.state[mode] = enabled
+
+	return mode.Switch(enabled).Render(buf)
+}
+
+func (s *ModeStack) flush() error {
+	if len(s.buf) == 0 {
+		return nil
+	}
+
+	_, err := s.target.Write(s.buf)
+
+	return err
+}
+
+// ---
+
+type modeChange struct {
+	mode    PrivateMode
+	enabled bool
+}
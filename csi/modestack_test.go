@@ -0,0 +1,96 @@
+package csi_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	. "github.com/pamburus/go-tst/tst"
+
+	"github.com/pamburus/go-ansi-esc/csi"
+)
+
+func TestModeStack(tt *testing.T) {
+	t := New(tt)
+
+	t.Run("PushPop", func(t Test) {
+		var buf strings.Builder
+		modes := csi.NewModeStack(&buf)
+
+		t.Expect(modes.Push(csi.ModeAlternateScreen, true)).ToSucceed()
+		t.Expect(modes.Push(csi.ModeCursorVisible, false)).ToSucceed()
+		t.Expect(modes.Push(csi.ModeCursorVisible, false)).ToSucceed()
+		t.Expect(buf.String()).ToEqual("\x1b[?1049h\x1b[?25l")
+		t.Expect(modes.Len()).ToEqual(3)
+		t.Expect(modes.Enabled(csi.ModeCursorVisible)).ToBeFalse()
+		t.Expect(modes.Enabled(csi.ModeAlternateScreen)).ToBeTrue()
+
+		buf.Reset()
+		t.Expect(modes.Pop()).ToSucceed()
+		t.Expect(buf.String()).ToEqual("")
+		t.Expect(modes.Pop()).ToSucceed()
+		t.Expect(buf.String()).ToEqual("\x1b[?25h")
+		t.Expect(modes.Pop()).ToSucceed()
+		t.Expect(buf.String()).ToEqual("\x1b[?25h\x1b[?1049l")
+		t.Expect(modes.Pop()).ToSucceed()
+		t.Expect(modes.Len()).ToEqual(0)
+	})
+
+	t.Run("Restore", func(t Test) {
+		var buf strings.Builder
+		modes := csi.NewModeStack(&buf)
+
+		t.Expect(modes.Push(csi.ModeAlternateScreen, true)).ToSucceed()
+		t.Expect(modes.Push(csi.ModeBracketedPaste, true)).ToSucceed()
+		t.Expect(modes.Push(csi.ModeSynchronizedOutput, true)).ToSucceed()
+		t.Expect(modes.Push(csi.ModeBracketedPaste, false)).ToSucceed()
+
+		buf.Reset()
+		t.Expect(modes.Restore()).ToSucceed()
+		t.Expect(buf.String()).ToEqual("\x1b[?2004h\x1b[?2026l\x1b[?2004l\x1b[?1049l")
+		t.Expect(modes.Len()).ToEqual(0)
+
+		buf.Reset()
+		t.Expect(modes.Restore()).ToSucceed()
+		t.Expect(buf.String()).ToEqual("")
+	})
+
+	t.Run("Panic", func(t Test) {
+		var buf strings.Builder
+
+		func() {
+			defer func() {
+				t.Expect(recover()).ToEqual("boom")
+			}()
+
+			modes := csi.NewModeStack(&buf)
+			defer modes.Restore()
+
+			_ = modes.Push(csi.ModeCursorVisible, false)
+			_ = modes.Push(csi.ModeSynchronizedOutput, true)
+
+			panic("boom")
+		}()
+
+		t.Expect(buf.String()).ToEqual("\x1b[?25l\x1b[?2026h\x1b[?2026l\x1b[?25h")
+	})
+
+	t.Run("Error", func(t Test) {
+		errTest := errors.New("test")
+		modes := csi.NewModeStack(failingWriter{errTest})
+
+		t.Expect(modes.Push(csi.ModeAlternateScreen, true)).ToFailWith(errTest)
+		t.Expect(modes.Restore()).ToFailWith(errTest)
+		t.Expect(modes.Len()).ToEqual(0)
+	})
+}
+
+// ---
+
+type failingWriter struct {
+	err error
+}
+
+func (w failingWriter) Write([]byte) (int, error) {
+	return 0, w.err
+}
@@ -0,0 +1,36 @@
+// Package csi provides facilities for dealing with ANSI Escape Sequences controlling
+// cursor position, erasing, editing and scrolling in a convenient way.
+//
+// These are CSI (Control Sequence Introducer) control functions defined in ECMA-48 standard
+// and DECSTBM defined by DEC VT100 and supported by virtually all terminals.
+// DEC private modes like alternate screen or bracketed paste are controlled by PrivateMode commands
+// and can be tracked and restored using ModeStack.
+// For CSI/SGR sequences controlling graphic rendition see package sgr.
+package csi
+
+// ---
+
+// Sequence is a sequence of commands.
+type Sequence []Command
+
+// Render produces binary string corresponding to the contained commands each starting with "\x1b[" (ESC/CSI).
+// Zero commands are skipped.
+func (s Sequence) Render(buf []byte) []byte {
+	for i := range s {
+		buf = s[i].Render(buf)
+	}
+
+	return buf
+}
+
+// Bytes returns binary string with the serialized sequence, the same as produces by Render but collected into a new byte slice.
+func (s Sequence) Bytes() []byte {
+	return s.Render(make([]byte, 0, 16*len(s)))
+}
+
+// ---
+
+const (
+	seqBegin = "\x1b["
+	seqNext  = ';'
+)
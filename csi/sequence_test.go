@@ -0,0 +1,62 @@
+package csi_test
+
+import (
+	"testing"
+
+	. "github.com/pamburus/go-tst/tst"
+
+	"github.com/pamburus/go-ansi-esc/csi"
+)
+
+func TestSequence(tt *testing.T) {
+	t := New(tt)
+
+	t.Run("Empty", func(t Test) {
+		t.Expect(csi.Sequence{}.Bytes()).ToEqual([]byte{})
+		t.Expect(csi.Sequence{}.Render(nil)).ToEqual([]byte(nil))
+	})
+
+	t.Run("Progress", func(t Test) {
+		t.Expect(
+			string(csi.Sequence{
+				csi.CursorUp(2),
+				csi.CursorHorizontalAbsolute(1),
+				csi.EraseInLine(csi.EraseAll),
+			}.Bytes()),
+		).ToEqual(
+			"\x1b[2A\x1b[1G\x1b[2K",
+		)
+	})
+
+	t.Run("SkipZero", func(t Test) {
+		t.Expect(
+			string(csi.Sequence{
+				csi.CursorUp(0),
+				csi.EraseInDisplay(csi.EraseToEnd),
+			}.Bytes()),
+		).ToEqual(
+			"\x1b[0J",
+		)
+	})
+
+	t.Run("Allocs", func(t Test) {
+		buf := make([]byte, 0, 100)
+		seq := csi.Sequence{csi.SetScrollingRegion(2, 24), csi.CursorPosition(24, 1), csi.ScrollUp(1)}
+		t.Expect(testing.AllocsPerRun(100, func() {
+			buf = seq.Render(buf[:0])
+		})).ToEqual(0.0)
+	})
+}
+
+func BenchmarkSequence(b *testing.B) {
+	buf := make([]byte, 0, 100)
+	seq := csi.Sequence{
+		csi.CursorUp(3),
+		csi.CursorPosition(12, 80),
+		csi.EraseInLine(csi.EraseToEnd),
+	}
+
+	for i := 0; i < b.N; i++ {
+		seq.Render(buf)
+	}
+}
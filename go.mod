@@ -4,5 +4,7 @@ go 1.22
 
 require (
 	github.com/pamburus/go-tst v0.6.0
+	github.com/rivo/uniseg v0.4.7
 	github.com/veggiemonk/strcase v0.0.0-20240108101409-9f441287a9a9
+	golang.org/x/text v0.14.0
 )
@@ -0,0 +1,279 @@
+// Package input provides a decoder of terminal input, that is a counterpart of the output oriented packages.
+//
+// It decodes bytes received from a terminal into typed events: key presses including CSI and SS3 key sequences
+// for arrows, navigation and function keys with xterm style modifiers like "\x1b[1;5A" for Ctrl+Up,
+// Alt prefixed keys, control characters, UTF-8 runes and bracketed paste blocks.
+//
+// The terminal must be in raw mode, otherwise input is line buffered and control keys are interpreted by the terminal
+// driver. Use for example golang.org/x/term package to switch the terminal to raw mode.
+//
+// A lone ESC byte is ambiguous because it may be either the Escape key or the beginning of an escape sequence
+// that has not been received completely yet. Reader resolves this by waiting for the rest of the sequence
+// for a short time, see WithEscapeTimeout.
+package input
+
+import (
+	"bytes"
+	"strconv"
+	"unicode/utf8"
+)
+
+// ---
+
+// Decode decodes the first event in data and returns the number of bytes consumed and the event.
+//
+// If data contains only the beginning of an event, Decode returns zero and nil event unless final is true.
+// The caller should then wait for more data and call Decode again or call it with final set to true
+// to decode the data as is when no more data arrived within a reasonable time or the input is exhausted.
+// In this case a lone ESC is decoded as KeyEscape, an incomplete escape sequence as UnknownEvent
+// and an incomplete bracketed paste block as PasteEvent with the text received so far.
+//
+// Decode returns zero only if data is empty or final is false.
+func Decode(data []byte, final bool) (int, Event) {
+	if len(data) == 0 {
+		return 0, nil
+	}
+
+	switch c := data[0]; {
+	case c == esc:
+		return decodeEscape(data, final)
+	case c < 0x20 || c == del:
+		return 1, controlKey(c)
+	case c < utf8.RuneSelf:
+		return 1, KeyEvent{Rune: rune(c)}
+	default:
+		if !final && !utf8.FullRune(data) {
+			return 0, nil
+		}
+
+		r, n := utf8.DecodeRune(data)
+
+		return n, KeyEvent{Rune: r}
+	}
+}
+
+// ---
+
+func decodeEscape(data []byte, final bool) (int, Event) {
+	if len(data) == 1 {
+		if !final {
+			return 0, nil
+		}
+
+		return 1, KeyEvent{Key: KeyEscape}
+	}
+
+	switch data[1] {
+	case '[':
+		return decodeCSI(data, final)
+	case 'O':
+		return decodeSS3(data, final)
+	case esc:
+		return 1, KeyEvent{Key: KeyEscape}
+	}
+
+	n, event := Decode(data[1:], final)
+	if n == 0 {
+		return 0, nil
+	}
+
+	key := event.(KeyEvent)
+	key.Modifiers |= ModAlt
+
+	return n + 1, key
+}
+
+func decodeSS3(data []byte, final bool) (int, Event) {
+	if len(data) == 2 {
+		if !final {
+			return 0, nil
+		}
+
+		return 2, KeyEvent{Rune: 'O', Modifiers: ModAlt}
+	}
+
+	if key, ok := letterKeys[data[2]]; ok {
+		return 3, KeyEvent{Key: key}
+	}
+
+	return 3, UnknownEvent{string(data[:3])}
+}
+
+func decodeCSI(data []byte, final bool) (int, Event) {
+	i := 2
+	for i < len(data) && data[i] >= 0x30 && data[i] <= 0x3f {
+		i++
+	}
+	paramsEnd := i
+
+	for i < len(data) && data[i] >= 0x20 && data[i] <= 0x2f {
+		i++
+	}
+
+	switch {
+	case i == len(data):
+		switch {
+		case !final:
+			return 0, nil
+		case len(data) == 2:
+			return 2, KeyEvent{Rune: '[', Modifiers: ModAlt}
+		default:
+			return len(data), UnknownEvent{string(data)}
+		}
+	case data[i] < 0x40 || data[i] > 0x7e:
+		return i, UnknownEvent{string(data[:i])}
+	}
+
+	n := i + 1
+	unknown := UnknownEvent{string(data[:n])}
+
+	if paramsEnd != i {
+		return n, unknown
+	}
+
+	params, ok := parseParams(data[2:paramsEnd])
+	if !ok {
+		return n, unknown
+	}
+
+	mod := modifier(params[1])
+
+	switch fin := data[i]; fin {
+	case '~':
+		if params[0] == pasteBeginParam {
+			return decodePaste(data, n, final)
+		}
+
+		if key, ok := tildeKeys[params[0]]; ok {
+			return n, KeyEvent{Key: key, Modifiers: mod}
+		}
+	case 'Z':
+		return n, KeyEvent{Key: KeyTab, Modifiers: mod | ModShift}
+	default:
+		if key, ok := letterKeys[fin]; ok && params[0] <= 1 {
+			return n, KeyEvent{Key: key, Modifiers: mod}
+		}
+	}
+
+	return n, unknown
+}
+
+func decodePaste(data []byte, begin int, final bool) (int, Event) {
+	end := bytes.Index(data[begin:], []byte(pasteEnd))
+	if end < 0 {
+		if !final {
+			return 0, nil
+		}
+
+		return len(data), PasteEvent{string(data[begin:])}
+	}
+
+	return begin + end + len(pasteEnd), PasteEvent{string(data[begin : begin+end])}
+}
+
+// parseParams parses up to two numeric parameters of CSI sequence.
+// Missing parameters are returned as zero, sub-parameters separated by colon are ignored.
+func parseParams(data []byte) ([2]int, bool) {
+	var params [2]int
+
+	if len(data) != 0 && data[0] >= '<' {
+		return params, false
+	}
+
+	for i, param := range bytes.Split(data, []byte(";")) {
+		if i >= len(params) {
+			break
+		}
+
+		if j := bytes.IndexByte(param, ':'); j >= 0 {
+			param = param[:j]
+		}
+
+		if len(param) == 0 {
+			continue
+		}
+
+		value, err := strconv.ParseUint(string(param), 10, 16)
+		if err != nil {
+			return params, false
+		}
+
+		params[i] = int(value)
+	}
+
+	return params, true
+}
+
+// modifier converts xterm modifier parameter value to Modifier.
+func modifier(param int) Modifier {
+	if param <= 1 {
+		return ModNone
+	}
+
+	return Modifier(param-1) & (ModShift | ModAlt | ModCtrl | ModMeta)
+}
+
+func controlKey(c byte) KeyEvent {
+	switch c {
+	case '\r':
+		return KeyEvent{Key: KeyEnter}
+	case '\t':
+		return KeyEvent{Key: KeyTab}
+	case del:
+		return KeyEvent{Key: KeyBackspace}
+	case 0:
+		return KeyEvent{Rune: ' ', Modifiers: ModCtrl}
+	case 0x1c, 0x1d, 0x1e, 0x1f:
+		return KeyEvent{Rune: rune(c) + 0x40, Modifiers: ModCtrl}
+	default:
+		return KeyEvent{Rune: rune(c) + 'a' - 1, Modifiers: ModCtrl}
+	}
+}
+
+// ---
+
+const (
+	esc             = 0x1b
+	del             = 0x7f
+	pasteBeginParam = 200
+	pasteBegin      = "\x1b[200~"
+	pasteEnd        = "\x1b[201~"
+)
+
+// letterKeys maps final bytes of CSI and SS3 sequences to keys.
+var letterKeys = map[byte]Key{
+	'A': KeyUp,
+	'B': KeyDown,
+	'C': KeyRight,
+	'D': KeyLeft,
+	'H': KeyHome,
+	'F': KeyEnd,
+	'P': KeyF1,
+	'Q': KeyF2,
+	'R': KeyF3,
+	'S': KeyF4,
+}
+
+// tildeKeys maps the first parameter of CSI sequences with '~' final byte to keys.
+var tildeKeys = map[int]Key{
+	1:  KeyHome,
+	2:  KeyInsert,
+	3:  KeyDelete,
+	4:  KeyEnd,
+	5:  KeyPageUp,
+	6:  KeyPageDown,
+	7:  KeyHome,
+	8:  KeyEnd,
+	11: KeyF1,
+	12: KeyF2,
+	13: KeyF3,
+	14: KeyF4,
+	15: KeyF5,
+	17: KeyF6,
+	18: KeyF7,
+	19: KeyF8,
+	20: KeyF9,
+	21: KeyF10,
+	23: KeyF11,
+	24: KeyF12,
+}
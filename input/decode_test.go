@@ -0,0 +1,138 @@
+package input_test
+
+import (
+	"testing"
+
+	. "github.com/pamburus/go-tst/tst"
+
+	"github.com/pamburus/go-ansi-esc/input"
+)
+
+func TestDecode(tt *testing.T) {
+	t := New(tt)
+
+	type result struct {
+		n     int
+		event input.Event
+	}
+
+	decode := func(data string, final bool) result {
+		n, event := input.Decode([]byte(data), final)
+
+		return result{n, event}
+	}
+
+	key := func(n int, k input.Key, mod input.Modifier) result {
+		return result{n, input.KeyEvent{Key: k, Modifiers: mod}}
+	}
+
+	char := func(n int, r rune, mod input.Modifier) result {
+		return result{n, input.KeyEvent{Rune: r, Modifiers: mod}}
+	}
+
+	t.Run("Empty", func(t Test) {
+		t.Expect(decode("", false)).ToEqual(result{})
+		t.Expect(decode("", true)).ToEqual(result{})
+	})
+
+	t.Run("Rune", func(t Test) {
+		t.Expect(decode("ab", false)).ToEqual(char(1, 'a', 0))
+		t.Expect(decode(" ", false)).ToEqual(char(1, ' ', 0))
+		t.Expect(decode("жx", false)).ToEqual(char(2, 'ж', 0))
+		t.Expect(decode("😀", false)).ToEqual(char(4, '😀', 0))
+		t.Expect(decode("😀"[:2], false)).ToEqual(result{})
+		t.Expect(decode("😀"[:2], true)).ToEqual(char(1, '�', 0))
+		t.Expect(decode("\xffa", false)).ToEqual(char(1, '�', 0))
+	})
+
+	t.Run("Control", func(t Test) {
+		t.Expect(decode("\r", false)).ToEqual(key(1, input.KeyEnter, 0))
+		t.Expect(decode("\t", false)).ToEqual(key(1, input.KeyTab, 0))
+		t.Expect(decode("\x7f", false)).ToEqual(key(1, input.KeyBackspace, 0))
+		t.Expect(decode("\x03", false)).ToEqual(char(1, 'c', input.ModCtrl))
+		t.Expect(decode("\x01", false)).ToEqual(char(1, 'a', input.ModCtrl))
+		t.Expect(decode("\x1a", false)).ToEqual(char(1, 'z', input.ModCtrl))
+		t.Expect(decode("\n", false)).ToEqual(char(1, 'j', input.ModCtrl))
+		t.Expect(decode("\x08", false)).ToEqual(char(1, 'h', input.ModCtrl))
+		t.Expect(decode("\x00", false)).ToEqual(char(1, ' ', input.ModCtrl))
+		t.Expect(decode("\x1c", false)).ToEqual(char(1, '\\', input.ModCtrl))
+		t.Expect(decode("\x1f", false)).ToEqual(char(1, '_', input.ModCtrl))
+	})
+
+	t.Run("Escape", func(t Test) {
+		t.Expect(decode("\x1b", false)).ToEqual(result{})
+		t.Expect(decode("\x1b", true)).ToEqual(key(1, input.KeyEscape, 0))
+		t.Expect(decode("\x1b\x1b", false)).ToEqual(key(1, input.KeyEscape, 0))
+		t.Expect(decode("\x1b\x1b[A", false)).ToEqual(key(1, input.KeyEscape, 0))
+	})
+
+	t.Run("Alt", func(t Test) {
+		t.Expect(decode("\x1bx", false)).ToEqual(char(2, 'x', input.ModAlt))
+		t.Expect(decode("\x1bжx", false)).ToEqual(char(3, 'ж', input.ModAlt))
+		t.Expect(decode("\x1b\xd0", false)).ToEqual(result{})
+		t.Expect(decode("\x1b\x03", false)).ToEqual(char(2, 'c', input.ModCtrl|input.ModAlt))
+		t.Expect(decode("\x1b\x7f", false)).ToEqual(key(2, input.KeyBackspace, input.ModAlt))
+		t.Expect(decode("\x1b\r", false)).ToEqual(key(2, input.KeyEnter, input.ModAlt))
+		t.Expect(decode("\x1b[", false)).ToEqual(result{})
+		t.Expect(decode("\x1b[", true)).ToEqual(char(2, '[', input.ModAlt))
+		t.Expect(decode("\x1bO", false)).ToEqual(result{})
+		t.Expect(decode("\x1bO", true)).ToEqual(char(2, 'O', input.ModAlt))
+	})
+
+	t.Run("CSI", func(t Test) {
+		t.Expect(decode("\x1b[A", false)).ToEqual(key(3, input.KeyUp, 0))
+		t.Expect(decode("\x1b[B", false)).ToEqual(key(3, input.KeyDown, 0))
+		t.Expect(decode("\x1b[C", false)).ToEqual(key(3, input.KeyRight, 0))
+		t.Expect(decode("\x1b[Dx", false)).ToEqual(key(3, input.KeyLeft, 0))
+		t.Expect(decode("\x1b[H", false)).ToEqual(key(3, input.KeyHome, 0))
+		t.Expect(decode("\x1b[F", false)).ToEqual(key(3, input.KeyEnd, 0))
+		t.Expect(decode("\x1b[1;5A", false)).ToEqual(key(6, input.KeyUp, input.ModCtrl))
+		t.Expect(decode("\x1b[1;2D", false)).ToEqual(key(6, input.KeyLeft, input.ModShift))
+		t.Expect(decode("\x1b[1;3C", false)).ToEqual(key(6, input.KeyRight, input.ModAlt))
+		t.Expect(decode("\x1b[1;8B", false)).ToEqual(key(6, input.KeyDown, input.ModShift|input.ModAlt|input.ModCtrl))
+		t.Expect(decode("\x1b[1;9H", false)).ToEqual(key(6, input.KeyHome, input.ModMeta))
+		t.Expect(decode("\x1b[1;2P", false)).ToEqual(key(6, input.KeyF1, input.ModShift))
+		t.Expect(decode("\x1b[Z", false)).ToEqual(key(3, input.KeyTab, input.ModShift))
+		t.Expect(decode("\x1b[1;5Z", false)).ToEqual(key(6, input.KeyTab, input.ModCtrl|input.ModShift))
+		t.Expect(decode("\x1b[1;5", false)).ToEqual(result{})
+		t.Expect(decode("\x1b[1;5", true)).ToEqual(result{5, input.UnknownEvent{Sequence: "\x1b[1;5"}})
+	})
+
+	t.Run("Tilde", func(t Test) {
+		t.Expect(decode("\x1b[2~", false)).ToEqual(key(4, input.KeyInsert, 0))
+		t.Expect(decode("\x1b[3~", false)).ToEqual(key(4, input.KeyDelete, 0))
+		t.Expect(decode("\x1b[3;5~", false)).ToEqual(key(6, input.KeyDelete, input.ModCtrl))
+		t.Expect(decode("\x1b[5~", false)).ToEqual(key(4, input.KeyPageUp, 0))
+		t.Expect(decode("\x1b[6;2~", false)).ToEqual(key(6, input.KeyPageDown, input.ModShift))
+		t.Expect(decode("\x1b[1~", false)).ToEqual(key(4, input.KeyHome, 0))
+		t.Expect(decode("\x1b[4~", false)).ToEqual(key(4, input.KeyEnd, 0))
+		t.Expect(decode("\x1b[15~", false)).ToEqual(key(5, input.KeyF5, 0))
+		t.Expect(decode("\x1b[24;3~", false)).ToEqual(key(7, input.KeyF12, input.ModAlt))
+		t.Expect(decode("\x1b[99~", false)).ToEqual(result{5, input.UnknownEvent{Sequence: "\x1b[99~"}})
+	})
+
+	t.Run("SS3", func(t Test) {
+		t.Expect(decode("\x1bOA", false)).ToEqual(key(3, input.KeyUp, 0))
+		t.Expect(decode("\x1bOP", false)).ToEqual(key(3, input.KeyF1, 0))
+		t.Expect(decode("\x1bOS", false)).ToEqual(key(3, input.KeyF4, 0))
+		t.Expect(decode("\x1bOF", false)).ToEqual(key(3, input.KeyEnd, 0))
+		t.Expect(decode("\x1bOx", false)).ToEqual(result{3, input.UnknownEvent{Sequence: "\x1bOx"}})
+	})
+
+	t.Run("Unknown", func(t Test) {
+		t.Expect(decode("\x1b[<0;10;5Mx", false)).ToEqual(result{10, input.UnknownEvent{Sequence: "\x1b[<0;10;5M"}})
+		t.Expect(decode("\x1b[?62;22c", false)).ToEqual(result{9, input.UnknownEvent{Sequence: "\x1b[?62;22c"}})
+		t.Expect(decode("\x1b[1 q", false)).ToEqual(result{5, input.UnknownEvent{Sequence: "\x1b[1 q"}})
+		t.Expect(decode("\x1b[99999A", false)).ToEqual(result{8, input.UnknownEvent{Sequence: "\x1b[99999A"}})
+		t.Expect(decode("\x1b[2A", false)).ToEqual(result{4, input.UnknownEvent{Sequence: "\x1b[2A"}})
+		t.Expect(decode("\x1b[1\x03", false)).ToEqual(result{3, input.UnknownEvent{Sequence: "\x1b[1"}})
+	})
+
+	t.Run("Paste", func(t Test) {
+		t.Expect(decode("\x1b[200~hello\r\n\x1b[A\x1b[201~x", false)).ToEqual(result{22, input.PasteEvent{Text: "hello\r\n\x1b[A"}})
+		t.Expect(decode("\x1b[200~\x1b[201~", false)).ToEqual(result{12, input.PasteEvent{}})
+		t.Expect(decode("\x1b[200~hel", false)).ToEqual(result{})
+		t.Expect(decode("\x1b[200~hel", true)).ToEqual(result{9, input.PasteEvent{Text: "hel"}})
+		t.Expect(decode("\x1b[201~", false)).ToEqual(result{6, input.UnknownEvent{Sequence: "\x1b[201~"}})
+	})
+}
@@ -0,0 +1,76 @@
+package input
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ---
+
+// Event is an input event, which is one of KeyEvent, PasteEvent or UnknownEvent.
+type Event interface {
+	fmt.Stringer
+	event()
+}
+
+// ---
+
+// KeyEvent is an event reported when a key is pressed.
+//
+// Rune is set only for KeyRune. Control characters are reported as KeyRune with ModCtrl and the corresponding
+// lower case letter or punctuation character, so Ctrl+C is reported as KeyEvent{KeyRune, 'c', ModCtrl}.
+// Enter, Tab, Backspace and Escape are reported as the dedicated keys.
+type KeyEvent struct {
+	Key       Key
+	Rune      rune
+	Modifiers Modifier
+}
+
+// String returns textual representation of e, like "Ctrl+Alt+Up", "a" or "Ctrl+c".
+func (e KeyEvent) String() string {
+	var sb strings.Builder
+	e.Modifiers.appendTo(&sb)
+
+	switch {
+	case e.Key != KeyRune:
+		sb.WriteString(e.Key.String())
+	case e.Rune == ' ':
+		sb.WriteString("Space")
+	default:
+		sb.WriteRune(e.Rune)
+	}
+
+	return sb.String()
+}
+
+func (KeyEvent) event() {}
+
+// ---
+
+// PasteEvent is an event reported when text is pasted with bracketed paste mode enabled.
+// Bracketed paste mode can be enabled using csi.ModeBracketedPaste.
+type PasteEvent struct {
+	Text string
+}
+
+// String returns textual representation of e.
+func (e PasteEvent) String() string {
+	return fmt.Sprintf("Paste(%q)", e.Text)
+}
+
+func (PasteEvent) event() {}
+
+// ---
+
+// UnknownEvent is an event reported for an escape sequence that is well-formed but not recognized,
+// like a mouse report or a reply to a query, or for an incomplete sequence left when input is exhausted.
+type UnknownEvent struct {
+	Sequence string
+}
+
+// String returns textual representation of e.
+func (e UnknownEvent) String() string {
+	return fmt.Sprintf("Unknown(%q)", e.Sequence)
+}
+
+func (UnknownEvent) event() {}
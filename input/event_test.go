@@ -0,0 +1,30 @@
+package input_test
+
+import (
+	"testing"
+
+	. "github.com/pamburus/go-tst/tst"
+
+	"github.com/pamburus/go-ansi-esc/input"
+)
+
+func TestEvent(tt *testing.T) {
+	t := New(tt)
+
+	t.Run("Key", func(t Test) {
+		t.Expect(input.KeyEvent{Rune: 'a'}.String()).ToEqual("a")
+		t.Expect(input.KeyEvent{Rune: 'ж', Modifiers: input.ModAlt}.String()).ToEqual("Alt+ж")
+		t.Expect(input.KeyEvent{Rune: 'c', Modifiers: input.ModCtrl}.String()).ToEqual("Ctrl+c")
+		t.Expect(input.KeyEvent{Rune: ' ', Modifiers: input.ModCtrl}.String()).ToEqual("Ctrl+Space")
+		t.Expect(input.KeyEvent{Key: input.KeyUp, Modifiers: input.ModCtrl | input.ModShift}.String()).ToEqual("Ctrl+Shift+Up")
+		t.Expect(input.KeyEvent{Key: input.KeyEnter}.String()).ToEqual("Enter")
+	})
+
+	t.Run("Paste", func(t Test) {
+		t.Expect(input.PasteEvent{Text: "a\nb"}.String()).ToEqual(`Paste("a\nb")`)
+	})
+
+	t.Run("Unknown", func(t Test) {
+		t.Expect(input.UnknownEvent{Sequence: "\x1b[<0;1;1M"}.String()).ToEqual(`Unknown("\x1b[<0;1;1M")`)
+	})
+}
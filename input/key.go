@@ -0,0 +1,135 @@
+package input
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ---
+
+// Key identifies a key reported by KeyEvent.
+type Key uint8
+
+// Key values.
+const (
+	KeyRune      Key = iota // Printable character or a control character reported as Ctrl with a character.
+	KeyEnter                // Enter or Return.
+	KeyTab                  // Tab.
+	KeyBackspace            // Backspace.
+	KeyEscape               // Escape.
+	KeyUp                   // Up arrow.
+	KeyDown                 // Down arrow.
+	KeyRight                // Right arrow.
+	KeyLeft                 // Left arrow.
+	KeyHome                 // Home.
+	KeyEnd                  // End.
+	KeyInsert               // Insert.
+	KeyDelete               // Delete.
+	KeyPageUp               // Page Up.
+	KeyPageDown             // Page Down.
+	KeyF1                   // F1.
+	KeyF2                   // F2.
+	KeyF3                   // F3.
+	KeyF4                   // F4.
+	KeyF5                   // F5.
+	KeyF6                   // F6.
+	KeyF7                   // F7.
+	KeyF8                   // F8.
+	KeyF9                   // F9.
+	KeyF10                  // F10.
+	KeyF11                  // F11.
+	KeyF12                  // F12.
+)
+
+// String returns textual representation of k, like "Up" or "F5".
+func (k Key) String() string {
+	if int(k) < len(keyNames) {
+		return keyNames[k]
+	}
+
+	return fmt.Sprintf("<!0x%02x>", uint8(k))
+}
+
+// ---
+
+// Modifier is a set of modifier keys held while a key is pressed.
+// Bit values follow xterm encoding of modifiers in key sequences, where the parameter value is 1 plus the bit set.
+type Modifier uint8
+
+// Modifier values.
+const (
+	ModShift Modifier = 1 << iota // Shift.
+	ModAlt                        // Alt or Option, also reported when a key is prefixed by ESC.
+	ModCtrl                       // Control.
+	ModMeta                       // Meta, Super or Command depending on the terminal.
+
+	ModNone Modifier = 0 // No modifiers.
+)
+
+// Contains returns true if m contains all modifiers of other.
+func (m Modifier) Contains(other Modifier) bool {
+	return m&other == other
+}
+
+// String returns textual representation of m, like "Ctrl+Shift".
+func (m Modifier) String() string {
+	if m == ModNone {
+		return "None"
+	}
+
+	var sb strings.Builder
+	m.appendTo(&sb)
+
+	return strings.TrimSuffix(sb.String(), "+")
+}
+
+func (m Modifier) appendTo(sb *strings.Builder) {
+	for _, mod := range modifierOrder {
+		if m.Contains(mod.value) {
+			sb.WriteString(mod.name)
+			sb.WriteByte('+')
+		}
+	}
+}
+
+// ---
+
+var keyNames = [...]string{
+	KeyRune:      "Rune",
+	KeyEnter:     "Enter",
+	KeyTab:       "Tab",
+	KeyBackspace: "Backspace",
+	KeyEscape:    "Escape",
+	KeyUp:        "Up",
+	KeyDown:      "Down",
+	KeyRight:     "Right",
+	KeyLeft:      "Left",
+	KeyHome:      "Home",
+	KeyEnd:       "End",
+	KeyInsert:    "Insert",
+	KeyDelete:    "Delete",
+	KeyPageUp:    "PageUp",
+	KeyPageDown:  "PageDown",
+	KeyF1:        "F1",
+	KeyF2:        "F2",
+	KeyF3:        "F3",
+	KeyF4:        "F4",
+	KeyF5:        "F5",
+	KeyF6:        "F6",
+	KeyF7:        "F7",
+	KeyF8:        "F8",
+	KeyF9:        "F9",
+	KeyF10:       "F10",
+	KeyF11:       "F11",
+	KeyF12:       "F12",
+}
+
+var modifierOrder = [...]struct {
+	value Modifier
+	name  string
+}{
+	{ModCtrl, "Ctrl"},
+	{ModAlt, "Alt"},
+	{ModShift, "Shift"},
+	{ModMeta, "Meta"},
+}
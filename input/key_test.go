@@ -0,0 +1,38 @@
+package input_test
+
+import (
+	"testing"
+
+	. "github.com/pamburus/go-tst/tst"
+
+	"github.com/pamburus/go-ansi-esc/input"
+)
+
+func TestKey(tt *testing.T) {
+	t := New(tt)
+
+	t.Expect(input.KeyRune.String()).ToEqual("Rune")
+	t.Expect(input.KeyUp.String()).ToEqual("Up")
+	t.Expect(input.KeyPageDown.String()).ToEqual("PageDown")
+	t.Expect(input.KeyF12.String()).ToEqual("F12")
+	t.Expect(input.Key(200).String()).ToEqual("<!0xc8>")
+}
+
+func TestModifier(tt *testing.T) {
+	t := New(tt)
+
+	t.Run("String", func(t Test) {
+		t.Expect(input.ModNone.String()).ToEqual("None")
+		t.Expect(input.ModShift.String()).ToEqual("Shift")
+		t.Expect((input.ModShift | input.ModCtrl).String()).ToEqual("Ctrl+Shift")
+		t.Expect((input.ModMeta | input.ModAlt | input.ModCtrl | input.ModShift).String()).ToEqual("Ctrl+Alt+Shift+Meta")
+	})
+
+	t.Run("Contains", func(t Test) {
+		mod := input.ModCtrl | input.ModAlt
+		t.Expect(mod.Contains(input.ModCtrl)).ToBeTrue()
+		t.Expect(mod.Contains(input.ModCtrl | input.ModAlt)).ToBeTrue()
+		t.Expect(mod.Contains(input.ModCtrl | input.ModShift)).ToBeFalse()
+		t.Expect(mod.Contains(input.ModNone)).ToBeTrue()
+	})
+}
@@ -0,0 +1,41 @@
+package input
+
+import (
+	"time"
+)
+
+// ---
+
+// DefaultEscapeTimeout is the default time to wait for the rest of an escape sequence after ESC byte.
+const DefaultEscapeTimeout = 50 * time.Millisecond
+
+// ---
+
+// Option is an option that can be passed to NewReader to customize its behavior.
+type Option func(*options)
+
+// WithEscapeTimeout returns an Option that sets the time to wait for the rest of an escape sequence
+// or UTF-8 encoded rune before decoding the received bytes as is, so that a lone ESC is reported as KeyEscape.
+// Default is DefaultEscapeTimeout.
+func WithEscapeTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.escapeTimeout = timeout
+	}
+}
+
+// ---
+
+type options struct {
+	escapeTimeout time.Duration
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{
+		escapeTimeout: DefaultEscapeTimeout,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o
+}
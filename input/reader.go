@@ -0,0 +1,119 @@
+package input
+
+import (
+	"bytes"
+	"io"
+	"time"
+)
+
+// ---
+
+// NewReader returns a new Reader that reads and decodes input events from r.
+func NewReader(r io.Reader, opts ...Option) *Reader {
+	o := newOptions(opts)
+
+	return &Reader{
+		r:       r,
+		timeout: o.escapeTimeout,
+	}
+}
+
+// Reader reads input events from an underlying reader, usually os.Stdin switched to raw mode.
+//
+// Reading from the underlying reader is done in a separate goroutine started by the first call to ReadEvent,
+// so that an incomplete escape sequence can be resolved after the escape timeout without blocking.
+// The goroutine reads ahead at most one chunk and exits when the underlying reader returns an error.
+// Reader must be the only consumer of the underlying reader, otherwise input may be lost.
+//
+// Reader is not safe for concurrent use.
+type Reader struct {
+	r       io.Reader
+	timeout time.Duration
+	results chan readResult
+	pending []byte
+	err     error
+}
+
+// ReadEvent blocks until the next event is received and returns it.
+// When the underlying reader returns an error, the remaining input is decoded and then the error is returned,
+// for example io.EOF.
+func (r *Reader) ReadEvent() (Event, error) {
+	expired := false
+
+	for {
+		n, event := Decode(r.pending, expired || r.err != nil)
+		if n != 0 {
+			r.pending = r.pending[n:]
+
+			return event, nil
+		}
+
+		if r.err != nil {
+			return nil, r.err
+		}
+
+		expired = !r.receive()
+	}
+}
+
+// Buffered returns the input that is received but not yet decoded.
+func (r *Reader) Buffered() []byte {
+	return r.pending
+}
+
+// ---
+
+// receive waits for the next chunk of input and appends it to the pending input.
+// It returns false if the pending input is incomplete and no more input arrived within the escape timeout.
+// Incomplete bracketed paste blocks are not subject to the timeout.
+func (r *Reader) receive() bool {
+	if r.results == nil {
+		r.results = make(chan readResult)
+		go r.run()
+	}
+
+	var timeout <-chan time.Time
+	if len(r.pending) != 0 && !bytes.HasPrefix(r.pending, []byte(pasteBegin)) {
+		timer := time.NewTimer(r.timeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	select {
+	case result := <-r.results:
+		if len(r.pending) == 0 {
+			r.pending = result.data
+		} else {
+			r.pending = append(r.pending, result.data...)
+		}
+		r.err = result.err
+
+		return true
+	case <-timeout:
+		return false
+	}
+}
+
+func (r *Reader) run() {
+	for {
+		data := make([]byte, readChunkSize)
+		n, err := r.r.Read(data)
+		if n == 0 && err == nil {
+			continue
+		}
+
+		r.results <- readResult{data[:n], err}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// ---
+
+const readChunkSize = 256
+
+type readResult struct {
+	data []byte
+	err  error
+}
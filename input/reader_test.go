@@ -0,0 +1,93 @@
+package input_test
+
+import (
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	. "github.com/pamburus/go-tst/tst"
+
+	"github.com/pamburus/go-ansi-esc/input"
+)
+
+func TestReader(tt *testing.T) {
+	t := New(tt)
+
+	readAll := func(r *input.Reader) ([]input.Event, error) {
+		var events []input.Event
+		for {
+			event, err := r.ReadEvent()
+			if err != nil {
+				return events, err
+			}
+			events = append(events, event)
+		}
+	}
+
+	t.Run("Chunked", func(t Test) {
+		pr, pw := io.Pipe()
+		go func() {
+			for _, chunk := range []string{"a\x1b", "[1;5", "A\x1b[200~x", "y\x1b[201", "~\xd0", "\xb6\x1b"} {
+				_, _ = pw.Write([]byte(chunk))
+			}
+			pw.Close()
+		}()
+
+		events, err := readAll(input.NewReader(pr, input.WithEscapeTimeout(time.Minute)))
+		t.Expect(err).ToEqual(io.EOF)
+		t.Expect(events).ToEqual([]input.Event{
+			input.KeyEvent{Rune: 'a'},
+			input.KeyEvent{Key: input.KeyUp, Modifiers: input.ModCtrl},
+			input.PasteEvent{Text: "xy"},
+			input.KeyEvent{Rune: 'ж'},
+			input.KeyEvent{Key: input.KeyEscape},
+		})
+	})
+
+	t.Run("EscapeTimeout", func(t Test) {
+		pr, pw := io.Pipe()
+		defer pw.Close()
+
+		r := input.NewReader(pr, input.WithEscapeTimeout(time.Millisecond))
+
+		go func() { _, _ = pw.Write([]byte("\x1b")) }()
+		t.Expect(r.ReadEvent()).ToSucceed().AndResult().ToEqual(input.KeyEvent{Key: input.KeyEscape})
+
+		go func() { _, _ = pw.Write([]byte("\x1b[")) }()
+		t.Expect(r.ReadEvent()).ToSucceed().AndResult().ToEqual(input.KeyEvent{Rune: '[', Modifiers: input.ModAlt})
+
+		go func() { _, _ = pw.Write([]byte("q")) }()
+		t.Expect(r.ReadEvent()).ToSucceed().AndResult().ToEqual(input.KeyEvent{Rune: 'q'})
+		t.Expect(r.Buffered()).ToEqual([]byte{})
+	})
+
+	t.Run("PasteIgnoresTimeout", func(t Test) {
+		pr, pw := io.Pipe()
+		defer pw.Close()
+
+		r := input.NewReader(pr, input.WithEscapeTimeout(time.Millisecond))
+
+		go func() {
+			_, _ = pw.Write([]byte("\x1b[200~abc"))
+			time.Sleep(20 * time.Millisecond)
+			_, _ = pw.Write([]byte("def\x1b[201~z"))
+		}()
+		t.Expect(r.ReadEvent()).ToSucceed().AndResult().ToEqual(input.PasteEvent{Text: "abcdef"})
+		t.Expect(r.Buffered()).ToEqual([]byte("z"))
+		t.Expect(r.ReadEvent()).ToSucceed().AndResult().ToEqual(input.KeyEvent{Rune: 'z'})
+	})
+
+	t.Run("Error", func(t Test) {
+		errTest := errors.New("test")
+		pr, pw := io.Pipe()
+		go func() {
+			_, _ = pw.Write([]byte("\x1b[200~ab"))
+			pw.CloseWithError(errTest)
+		}()
+
+		events, err := readAll(input.NewReader(pr))
+		t.Expect(err).ToEqual(errTest)
+		t.Expect(events).ToEqual([]input.Event{input.PasteEvent{Text: "ab"}})
+	})
+}
@@ -0,0 +1,144 @@
+package osc
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io"
+)
+
+// ---
+
+// Commonly supported Selection values.
+const (
+	SelectionClipboard Selection = 'c' // System clipboard.
+	SelectionPrimary   Selection = 'p' // Primary selection, that is pasted by middle mouse button in X11.
+	SelectionSelect    Selection = 's' // Selection configured in the terminal, either primary selection or clipboard.
+)
+
+// Selection identifies a selection or clipboard in OSC 52 sequences.
+type Selection byte
+
+// ---
+
+// RenderClipboard appends OSC 52 sequence that sets contents of the selections to data.
+// It returns ErrClipboardTooLarge and buf as is if the encoded data exceeds the limit.
+// Selections, limit and passthrough can be changed using WithSelection, WithClipboardLimit and WithPassthrough options.
+func RenderClipboard(buf, data []byte, opts ...Option) ([]byte, error) {
+	o := newOptions(opts)
+
+	size := base64.StdEncoding.EncodedLen(len(data))
+	if o.limit > 0 && size > o.limit {
+		return buf, ErrClipboardTooLarge{size}
+	}
+
+	seq := make([]byte, 0, size+16)
+	seq = append(seq, oscClipboardBegin...)
+	seq = appendSelections(seq, o.selections)
+	seq = append(seq, ';')
+	seq = base64.StdEncoding.AppendEncode(seq, data)
+	seq = appendEnd(seq, o.passthrough)
+
+	return o.passthrough.Wrap(buf, seq), nil
+}
+
+// WriteClipboard writes OSC 52 sequence that sets contents of the selections to data to w.
+// It accepts the same options as RenderClipboard.
+func WriteClipboard(w io.Writer, data []byte, opts ...Option) error {
+	buf, err := RenderClipboard(nil, data, opts...)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(buf)
+
+	return err
+}
+
+// ReadClipboard sends OSC 52 query for contents of the first selection to the terminal and waits for the reply.
+// Many terminals do not reply to such queries or require it to be enabled explicitly for security reasons,
+// in which case ErrClipboardNotReadable is returned.
+// See QueryColors for details on timeout handling.
+func ReadClipboard(terminal io.ReadWriter, opts ...Option) ([]byte, error) {
+	o := newOptions(opts)
+
+	selection := SelectionClipboard
+	if len(o.selections) != 0 {
+		selection = o.selections[0]
+	}
+
+	seq := append([]byte(oscClipboardBegin), byte(selection), ';', '?')
+	seq = appendEnd(seq, o.passthrough)
+
+	var data []byte
+	var replyErr error
+	received := false
+
+	err := exchange(terminal, o.passthrough.Wrap(nil, seq), o.timeout, func(token []byte) {
+		if received || !bytes.HasPrefix(token, []byte(oscClipboardBegin)) {
+			return
+		}
+
+		received = true
+		_, data, replyErr = ParseClipboardReply(token)
+	})
+
+	switch {
+	case err != nil:
+		return nil, err
+	case !received:
+		return nil, ErrClipboardNotReadable{selection}
+	default:
+		return data, replyErr
+	}
+}
+
+// ParseClipboardReply parses OSC 52 sequence sent by the terminal in reply to a clipboard query
+// and returns the selections and the decoded contents.
+func ParseClipboardReply(reply []byte) ([]Selection, []byte, error) {
+	body, ok := oscBody(reply)
+	if !ok || !bytes.HasPrefix(body, []byte("52;")) {
+		return nil, nil, ErrInvalidClipboardReply{Value: string(reply)}
+	}
+
+	body = body[3:]
+	i := bytes.IndexByte(body, ';')
+	if i < 0 {
+		return nil, nil, ErrInvalidClipboardReply{Value: string(reply)}
+	}
+
+	selections := make([]Selection, i)
+	for j, c := range body[:i] {
+		selections[j] = Selection(c)
+	}
+
+	data, err := base64.StdEncoding.AppendDecode(nil, body[i+1:])
+	if err != nil {
+		return nil, nil, ErrInvalidClipboardReply{string(reply), err}
+	}
+
+	return selections, data, nil
+}
+
+// ---
+
+func appendSelections(buf []byte, selections []Selection) []byte {
+	for _, s := range selections {
+		buf = append(buf, byte(s))
+	}
+
+	return buf
+}
+
+// appendEnd appends the terminator of OSC sequence.
+// BEL is used in case of passthrough so that the sequence does not terminate the wrapping DCS sequence.
+func appendEnd(buf []byte, passthrough Passthrough) []byte {
+	if passthrough != PassthroughNone {
+		return append(buf, '\a')
+	}
+
+	return append(buf, oscEnd...)
+}
+
+// ---
+
+const oscClipboardBegin = oscBegin + "52;"
@@ -0,0 +1,140 @@
+package osc_test
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/pamburus/go-tst/tst"
+
+	"github.com/pamburus/go-ansi-esc/osc"
+)
+
+func TestRenderClipboard(tt *testing.T) {
+	t := New(tt)
+
+	t.Run("Default", func(t Test) {
+		t.Expect(osc.RenderClipboard([]byte("x"), []byte("token"))).ToSucceed().AndResult().ToEqual([]byte("x\x1b]52;c;dG9rZW4=\x1b\\"))
+		t.Expect(osc.RenderClipboard(nil, nil)).ToSucceed().AndResult().ToEqual([]byte("\x1b]52;c;\x1b\\"))
+	})
+
+	t.Run("Selection", func(t Test) {
+		t.Expect(osc.RenderClipboard(nil, []byte("a"), osc.WithSelection(osc.SelectionClipboard, osc.SelectionPrimary))).
+			ToSucceed().AndResult().ToEqual([]byte("\x1b]52;cp;YQ==\x1b\\"))
+		t.Expect(osc.RenderClipboard(nil, []byte("a"), osc.WithSelection(osc.SelectionSelect))).
+			ToSucceed().AndResult().ToEqual([]byte("\x1b]52;s;YQ==\x1b\\"))
+	})
+
+	t.Run("Limit", func(t Test) {
+		data := []byte(strings.Repeat("a", 75003))
+		buf, err := osc.RenderClipboard([]byte("x"), data)
+		t.Expect(err).ToEqual(osc.ErrClipboardTooLarge{Value: 100004})
+		t.Expect(buf).ToEqual([]byte("x"))
+		t.Expect(osc.RenderClipboard(nil, data[:75000])).ToSucceed()
+		t.Expect(osc.RenderClipboard(nil, []byte("abcd"), osc.WithClipboardLimit(4))).ToFailWith(osc.ErrClipboardTooLarge{Value: 8})
+		t.Expect(osc.RenderClipboard(nil, data, osc.WithClipboardLimit(0))).ToSucceed()
+	})
+
+	t.Run("Passthrough", func(t Test) {
+		t.Expect(osc.RenderClipboard(nil, []byte("a"), osc.WithPassthrough(osc.PassthroughTmux))).
+			ToSucceed().AndResult().ToEqual([]byte("\x1bPtmux;\x1b\x1b]52;c;YQ==\a\x1b\\"))
+		t.Expect(osc.RenderClipboard(nil, []byte("a"), osc.WithPassthrough(osc.PassthroughScreen))).
+			ToSucceed().AndResult().ToEqual([]byte("\x1bP\x1b]52;c;YQ==\a\x1b\\"))
+	})
+}
+
+func TestWriteClipboard(tt *testing.T) {
+	t := New(tt)
+
+	var buf strings.Builder
+	t.Expect(osc.WriteClipboard(&buf, []byte("a"))).ToSucceed()
+	t.Expect(buf.String()).ToEqual("\x1b]52;c;YQ==\x1b\\")
+
+	buf.Reset()
+	t.Expect(osc.WriteClipboard(&buf, []byte("abc"), osc.WithClipboardLimit(1))).ToFailWith(osc.ErrClipboardTooLarge{})
+	t.Expect(buf.String()).ToEqual("")
+
+	errTest := errors.New("test")
+	t.Expect(osc.WriteClipboard(failingWriter{errTest}, []byte("a"))).ToFailWith(errTest)
+}
+
+func TestReadClipboard(tt *testing.T) {
+	t := New(tt)
+
+	t.Run("Reply", func(t Test) {
+		terminal := newFakeTerminal(map[string]string{
+			"\x1b]52;c;?\x1b\\": "\x1b]52;c;" + base64.StdEncoding.EncodeToString([]byte("secret token")) + "\a",
+		})
+		t.Expect(osc.ReadClipboard(terminal)).ToSucceed().AndResult().ToEqual([]byte("secret token"))
+		t.Expect(terminal.request()).ToEqual("\x1b]52;c;?\x1b\\\x1b[c")
+	})
+
+	t.Run("Selection", func(t Test) {
+		terminal := newFakeTerminal(map[string]string{
+			"\x1b]52;p;?\x1b\\": "\x1b]52;p;YQ==\x1b\\",
+		})
+		t.Expect(osc.ReadClipboard(terminal, osc.WithSelection(osc.SelectionPrimary, osc.SelectionClipboard))).
+			ToSucceed().AndResult().ToEqual([]byte("a"))
+	})
+
+	t.Run("Passthrough", func(t Test) {
+		terminal := newFakeTerminal(map[string]string{
+			"\x1bPtmux;\x1b\x1b]52;c;?\a": "\x1b]52;c;YQ==\x1b\\",
+		})
+		t.Expect(osc.ReadClipboard(terminal, osc.WithPassthrough(osc.PassthroughTmux))).ToSucceed().AndResult().ToEqual([]byte("a"))
+		t.Expect(terminal.request()).ToEqual("\x1bPtmux;\x1b\x1b]52;c;?\a\x1b\\\x1b[c")
+	})
+
+	t.Run("NotReadable", func(t Test) {
+		t.Expect(osc.ReadClipboard(newFakeTerminal(nil))).ToFailWith(osc.ErrClipboardNotReadable{Value: osc.SelectionClipboard})
+	})
+
+	t.Run("Invalid", func(t Test) {
+		terminal := newFakeTerminal(map[string]string{
+			"\x1b]52;c;?\x1b\\": "\x1b]52;c;!!\x1b\\",
+		})
+		t.Expect(osc.ReadClipboard(terminal)).ToFailWith(osc.ErrInvalidClipboardReply{})
+	})
+
+	t.Run("Timeout", func(t Test) {
+		terminal := newFakeTerminal(nil)
+		terminal.silent = true
+		t.Expect(osc.ReadClipboard(terminal, osc.WithTimeout(time.Millisecond))).ToFailWith(osc.ErrTimeout{})
+	})
+}
+
+func TestParseClipboardReply(tt *testing.T) {
+	t := New(tt)
+
+	selections, data, err := osc.ParseClipboardReply([]byte("\x1b]52;cp;YWJj\x1b\\"))
+	t.Expect(err).ToSucceed()
+	t.Expect(selections).ToEqual([]osc.Selection{osc.SelectionClipboard, osc.SelectionPrimary})
+	t.Expect(data).ToEqual([]byte("abc"))
+
+	for _, reply := range []string{
+		"",
+		"\x1b]52;c;YQ==",
+		"\x1b]11;c;YQ==\x1b\\",
+		"\x1b]52;YQ==\x1b\\",
+		"\x1b]52;c;Y\x1b\\",
+	} {
+		_, _, err := osc.ParseClipboardReply([]byte(reply))
+		t.Expect(err).ToFailWith(osc.ErrInvalidClipboardReply{Value: reply})
+	}
+
+	_, _, err = osc.ParseClipboardReply([]byte("\x1b]52;c;Y\x1b\\"))
+	var corrupt base64.CorruptInputError
+	t.Expect(errors.As(err, &corrupt)).ToBeTrue()
+}
+
+// ---
+
+type failingWriter struct {
+	err error
+}
+
+func (w failingWriter) Write([]byte) (int, error) {
+	return 0, w.err
+}
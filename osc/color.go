@@ -0,0 +1,79 @@
+package osc
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pamburus/go-ansi-esc/sgr"
+)
+
+// ---
+
+// ParseColor parses color specification used in terminal replies to color queries.
+// Supported forms are "rgb:r/g/b" and "rgba:r/g/b/a" with 1 to 4 hexadecimal digits per component
+// scaled to the full range, and legacy "#rgb" form with 1 to 4 hexadecimal digits per component
+// where the most significant bits are used. Alpha component is ignored.
+func ParseColor(spec string) (sgr.RGBColor, error) {
+	switch {
+	case strings.HasPrefix(spec, "rgb:"):
+		return parseScaledColor(spec, spec[4:], 3)
+	case strings.HasPrefix(spec, "rgba:"):
+		return parseScaledColor(spec, spec[5:], 4)
+	case strings.HasPrefix(spec, "#"):
+		return parseLegacyColor(spec)
+	default:
+		return 0, ErrInvalidColorSpec{spec}
+	}
+}
+
+// ---
+
+func parseScaledColor(spec, text string, n int) (sgr.RGBColor, error) {
+	parts := strings.Split(text, "/")
+	if len(parts) != n {
+		return 0, ErrInvalidColorSpec{spec}
+	}
+
+	var components [3]uint8
+	for i := range components {
+		v, ok := parseHex(parts[i])
+		if !ok {
+			return 0, ErrInvalidColorSpec{spec}
+		}
+
+		limit := uint64(1)<<(4*len(parts[i])) - 1
+		components[i] = uint8((v*255 + limit/2) / limit)
+	}
+
+	return sgr.RGB(components[0], components[1], components[2]), nil
+}
+
+func parseLegacyColor(spec string) (sgr.RGBColor, error) {
+	text := spec[1:]
+	if len(text) == 0 || len(text)%3 != 0 || len(text) > 12 {
+		return 0, ErrInvalidColorSpec{spec}
+	}
+
+	n := len(text) / 3
+	var components [3]uint8
+	for i := range components {
+		v, ok := parseHex(text[i*n : (i+1)*n])
+		if !ok {
+			return 0, ErrInvalidColorSpec{spec}
+		}
+
+		components[i] = uint8(v << 16 >> (4 * n) >> 8)
+	}
+
+	return sgr.RGB(components[0], components[1], components[2]), nil
+}
+
+func parseHex(text string) (uint64, bool) {
+	if len(text) == 0 || len(text) > 4 {
+		return 0, false
+	}
+
+	v, err := strconv.ParseUint(text, 16, 16)
+
+	return v, err == nil
+}
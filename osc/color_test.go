@@ -0,0 +1,50 @@
+package osc_test
+
+import (
+	"testing"
+
+	. "github.com/pamburus/go-tst/tst"
+
+	"github.com/pamburus/go-ansi-esc/osc"
+	"github.com/pamburus/go-ansi-esc/sgr"
+)
+
+func TestParseColor(tt *testing.T) {
+	t := New(tt)
+
+	t.Run("Scaled", func(t Test) {
+		t.Expect(osc.ParseColor("rgb:ffff/8080/0000")).ToSucceed().AndResult().ToEqual(sgr.RGB(255, 128, 0))
+		t.Expect(osc.ParseColor("rgb:1e1e/1e1e/2e2e")).ToSucceed().AndResult().ToEqual(sgr.RGB(30, 30, 46))
+		t.Expect(osc.ParseColor("rgb:f/8/0")).ToSucceed().AndResult().ToEqual(sgr.RGB(255, 136, 0))
+		t.Expect(osc.ParseColor("rgb:ff/80/00")).ToSucceed().AndResult().ToEqual(sgr.RGB(255, 128, 0))
+		t.Expect(osc.ParseColor("rgb:fff/800/000")).ToSucceed().AndResult().ToEqual(sgr.RGB(255, 128, 0))
+		t.Expect(osc.ParseColor("rgba:ffff/0000/0000/ffff")).ToSucceed().AndResult().ToEqual(sgr.RGB(255, 0, 0))
+	})
+
+	t.Run("Legacy", func(t Test) {
+		t.Expect(osc.ParseColor("#f80")).ToSucceed().AndResult().ToEqual(sgr.RGB(0xf0, 0x80, 0))
+		t.Expect(osc.ParseColor("#ff8000")).ToSucceed().AndResult().ToEqual(sgr.RGB(255, 128, 0))
+		t.Expect(osc.ParseColor("#fff800000")).ToSucceed().AndResult().ToEqual(sgr.RGB(255, 128, 0))
+		t.Expect(osc.ParseColor("#ffff80000000")).ToSucceed().AndResult().ToEqual(sgr.RGB(255, 128, 0))
+	})
+
+	t.Run("Invalid", func(t Test) {
+		for _, spec := range []string{
+			"",
+			"red",
+			"rgb:",
+			"rgb:ff/ff",
+			"rgb:ff/ff/ff/ff",
+			"rgb:fffff/0/0",
+			"rgb:gg/00/00",
+			"rgb:/00/00",
+			"rgba:ff/ff/ff",
+			"#",
+			"#ff",
+			"#fffffffffffffff",
+			"#xyz",
+		} {
+			t.Expect(osc.ParseColor(spec)).ToFailWith(osc.ErrInvalidColorSpec{Value: spec})
+		}
+	})
+}
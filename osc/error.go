@@ -0,0 +1,138 @@
+package osc
+
+import (
+	"fmt"
+	"time"
+)
+
+// ---
+
+// ErrInvalidColorSpec is an error that occurs in case of parsing an invalid color specification.
+type ErrInvalidColorSpec struct {
+	Value string
+}
+
+// Error returns the error message.
+func (e ErrInvalidColorSpec) Error() string {
+	return fmt.Sprintf("invalid color specification %q", e.Value)
+}
+
+// Is returns true if e is a sub-class of err.
+func (e ErrInvalidColorSpec) Is(err error) bool {
+	if other, ok := err.(ErrInvalidColorSpec); ok {
+		return other.Value == "" || other.Value == e.Value
+	}
+
+	return false
+}
+
+// ---
+
+// ErrTimeout is an error that occurs in case terminal does not complete its reply within the timeout.
+type ErrTimeout struct {
+	Value time.Duration
+}
+
+// Error returns the error message.
+func (e ErrTimeout) Error() string {
+	return fmt.Sprintf("no reply from terminal within %v", e.Value)
+}
+
+// Is returns true if e is a sub-class of err.
+func (e ErrTimeout) Is(err error) bool {
+	if other, ok := err.(ErrTimeout); ok {
+		return other.Value == 0 || other.Value == e.Value
+	}
+
+	return false
+}
+
+// ---
+
+// ErrUnsupportedQuery is an error that occurs in case terminal does not reply to a query it does not support.
+type ErrUnsupportedQuery struct {
+	Value ColorQuery
+}
+
+// Error returns the error message.
+func (e ErrUnsupportedQuery) Error() string {
+	return fmt.Sprintf("terminal does not support query for %v", e.Value)
+}
+
+// Is returns true if e is a sub-class of err.
+func (e ErrUnsupportedQuery) Is(err error) bool {
+	if other, ok := err.(ErrUnsupportedQuery); ok {
+		return other.Value == ColorQuery{} || other.Value == e.Value
+	}
+
+	return false
+}
+
+// ---
+
+// ErrClipboardTooLarge is an error that occurs in case base64 encoded clipboard data exceeds the limit.
+type ErrClipboardTooLarge struct {
+	Value int
+}
+
+// Error returns the error message.
+func (e ErrClipboardTooLarge) Error() string {
+	return fmt.Sprintf("encoded clipboard data size %d exceeds the limit", e.Value)
+}
+
+// Is returns true if e is a sub-class of err.
+func (e ErrClipboardTooLarge) Is(err error) bool {
+	if other, ok := err.(ErrClipboardTooLarge); ok {
+		return other.Value == 0 || other.Value == e.Value
+	}
+
+	return false
+}
+
+// ---
+
+// ErrClipboardNotReadable is an error that occurs in case terminal does not reply to a clipboard query.
+type ErrClipboardNotReadable struct {
+	Value Selection
+}
+
+// Error returns the error message.
+func (e ErrClipboardNotReadable) Error() string {
+	return fmt.Sprintf("terminal does not allow reading selection %q", e.Value)
+}
+
+// Is returns true if e is a sub-class of err.
+func (e ErrClipboardNotReadable) Is(err error) bool {
+	if other, ok := err.(ErrClipboardNotReadable); ok {
+		return other.Value == 0 || other.Value == e.Value
+	}
+
+	return false
+}
+
+// ---
+
+// ErrInvalidClipboardReply is an error that occurs in case of parsing an invalid reply to a clipboard query.
+type ErrInvalidClipboardReply struct {
+	Value   string
+	details error
+}
+
+// Error returns the error message.
+func (e ErrInvalidClipboardReply) Error() string {
+	return fmt.Sprintf("invalid clipboard reply %q", e.Value)
+}
+
+// Unwrap returns the underlying error.
+func (e ErrInvalidClipboardReply) Unwrap() error {
+	return e.details
+}
+
+// Is returns true if e is a sub-class of err.
+func (e ErrInvalidClipboardReply) Is(err error) bool {
+	if other, ok := err.(ErrInvalidClipboardReply); ok {
+		return other.Value == "" || other.Value == e.Value
+	}
+
+	return false
+}
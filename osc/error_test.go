@@ -0,0 +1,70 @@
+package osc_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/pamburus/go-tst/tst"
+
+	"github.com/pamburus/go-ansi-esc/osc"
+)
+
+func TestErrors(tt *testing.T) {
+	t := New(tt)
+
+	t.Run("InvalidColorSpec", func(t Test) {
+		err := osc.ErrInvalidColorSpec{Value: "x"}
+		t.Expect(err.Error()).ToEqual(`invalid color specification "x"`)
+		t.Expect(errors.Is(err, osc.ErrInvalidColorSpec{})).ToBeTrue()
+		t.Expect(errors.Is(err, osc.ErrInvalidColorSpec{Value: "x"})).ToBeTrue()
+		t.Expect(errors.Is(err, osc.ErrInvalidColorSpec{Value: "y"})).ToBeFalse()
+		t.Expect(errors.Is(err, osc.ErrTimeout{})).ToBeFalse()
+	})
+
+	t.Run("Timeout", func(t Test) {
+		err := osc.ErrTimeout{Value: time.Second}
+		t.Expect(err.Error()).ToEqual("no reply from terminal within 1s")
+		t.Expect(errors.Is(err, osc.ErrTimeout{})).ToBeTrue()
+		t.Expect(errors.Is(err, osc.ErrTimeout{Value: time.Second})).ToBeTrue()
+		t.Expect(errors.Is(err, osc.ErrTimeout{Value: time.Minute})).ToBeFalse()
+		t.Expect(errors.Is(err, osc.ErrInvalidColorSpec{})).ToBeFalse()
+	})
+
+	t.Run("UnsupportedQuery", func(t Test) {
+		err := osc.ErrUnsupportedQuery{Value: osc.BackgroundColorQuery}
+		t.Expect(err.Error()).ToEqual("terminal does not support query for BackgroundColor")
+		t.Expect(errors.Is(err, osc.ErrUnsupportedQuery{})).ToBeTrue()
+		t.Expect(errors.Is(err, osc.ErrUnsupportedQuery{Value: osc.BackgroundColorQuery})).ToBeTrue()
+		t.Expect(errors.Is(err, osc.ErrUnsupportedQuery{Value: osc.CursorColorQuery})).ToBeFalse()
+		t.Expect(errors.Is(err, osc.ErrTimeout{})).ToBeFalse()
+	})
+
+	t.Run("ClipboardTooLarge", func(t Test) {
+		err := osc.ErrClipboardTooLarge{Value: 8}
+		t.Expect(err.Error()).ToEqual("encoded clipboard data size 8 exceeds the limit")
+		t.Expect(errors.Is(err, osc.ErrClipboardTooLarge{})).ToBeTrue()
+		t.Expect(errors.Is(err, osc.ErrClipboardTooLarge{Value: 8})).ToBeTrue()
+		t.Expect(errors.Is(err, osc.ErrClipboardTooLarge{Value: 4})).ToBeFalse()
+		t.Expect(errors.Is(err, osc.ErrTimeout{})).ToBeFalse()
+	})
+
+	t.Run("ClipboardNotReadable", func(t Test) {
+		err := osc.ErrClipboardNotReadable{Value: osc.SelectionPrimary}
+		t.Expect(err.Error()).ToEqual("terminal does not allow reading selection 'p'")
+		t.Expect(errors.Is(err, osc.ErrClipboardNotReadable{})).ToBeTrue()
+		t.Expect(errors.Is(err, osc.ErrClipboardNotReadable{Value: osc.SelectionPrimary})).ToBeTrue()
+		t.Expect(errors.Is(err, osc.ErrClipboardNotReadable{Value: osc.SelectionClipboard})).ToBeFalse()
+		t.Expect(errors.Is(err, osc.ErrClipboardTooLarge{})).ToBeFalse()
+	})
+
+	t.Run("InvalidClipboardReply", func(t Test) {
+		err := osc.ErrInvalidClipboardReply{Value: "x"}
+		t.Expect(err.Error()).ToEqual(`invalid clipboard reply "x"`)
+		t.Expect(errors.Unwrap(err)).ToEqual(nil)
+		t.Expect(errors.Is(err, osc.ErrInvalidClipboardReply{})).ToBeTrue()
+		t.Expect(errors.Is(err, osc.ErrInvalidClipboardReply{Value: "x"})).ToBeTrue()
+		t.Expect(errors.Is(err, osc.ErrInvalidClipboardReply{Value: "y"})).ToBeFalse()
+		t.Expect(errors.Is(err, osc.ErrInvalidColorSpec{})).ToBeFalse()
+	})
+}
@@ -0,0 +1,280 @@
+package osc
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/pamburus/go-ansi-esc/sgr"
+)
+
+// ---
+
+// QueryColors sends the given queries to the terminal and waits for the replies.
+// It returns a reply for each query in the same order, with OK set to false for queries the terminal did not answer.
+//
+// Replies are matched to queries by their contents, so the order in which the terminal sends them does not matter.
+// Any other input received from the terminal before the reply to DA1 request is discarded.
+//
+// If the terminal implements SetReadDeadline method like *os.File does, it is used to implement the timeout.
+// Otherwise each read is done in a separate goroutine that is left blocked in case of timeout
+// and consumes the next input received from the terminal.
+func QueryColors(terminal io.ReadWriter, queries []ColorQuery, opts ...Option) ([]ColorReply, error) {
+	o := newOptions(opts)
+
+	replies := make([]ColorReply, len(queries))
+	for i, query := range queries {
+		replies[i].Query = query
+	}
+
+	var request []byte
+	for _, query := range queries {
+		request = query.Render(request)
+	}
+
+	err := exchange(terminal, request, o.timeout, func(token []byte) {
+		handleReply(replies, token)
+	})
+
+	return replies, err
+}
+
+// QueryColor sends a single query to the terminal and returns the color from the reply.
+// It returns ErrUnsupportedQuery if the terminal did not answer the query.
+func QueryColor(terminal io.ReadWriter, query ColorQuery, opts ...Option) (sgr.RGBColor, error) {
+	replies, err := QueryColors(terminal, []ColorQuery{query}, opts...)
+	if err != nil {
+		return 0, err
+	}
+
+	if !replies[0].OK {
+		return 0, ErrUnsupportedQuery{query}
+	}
+
+	return replies[0].Color, nil
+}
+
+// QueryBackground queries the terminal background color and returns whether it is dark or light.
+// The result can be passed to sgr.WithBackground option to resolve sgr.AdaptiveColor values.
+func QueryBackground(terminal io.ReadWriter, opts ...Option) (sgr.Background, error) {
+	color, err := QueryColor(terminal, BackgroundColorQuery, opts...)
+	if err != nil {
+		return sgr.BackgroundDark, err
+	}
+
+	return sgr.BackgroundOf(color), nil
+}
+
+// QueryPalette queries all 256 palette entries from the terminal and returns the resulting palette.
+// Entries that the terminal did not answer are taken from sgr.DefaultPalette.
+// It returns ErrUnsupportedQuery if the terminal did not answer any of the queries.
+func QueryPalette(terminal io.ReadWriter, opts ...Option) (sgr.Palette, error) {
+	queries := make([]ColorQuery, len(sgr.Palette{}))
+	for i := range queries {
+		queries[i] = PaletteColorQuery(uint8(i))
+	}
+
+	palette := sgr.DefaultPalette
+
+	replies, err := QueryColors(terminal, queries, opts...)
+	if err != nil {
+		return palette, err
+	}
+
+	answered := false
+	for i, reply := range replies {
+		if reply.OK {
+			palette[i] = reply.Color
+			answered = true
+		}
+	}
+
+	if !answered {
+		return palette, ErrUnsupportedQuery{queries[0]}
+	}
+
+	return palette, nil
+}
+
+// ---
+
+// ColorReply is a reply to a ColorQuery.
+type ColorReply struct {
+	Query ColorQuery
+	Color sgr.RGBColor
+	OK    bool
+}
+
+// ---
+
+// exchange writes the request followed by DA1 request to the terminal and calls handle for each token
+// received from the terminal until the reply to DA1 request is received.
+func exchange(terminal io.ReadWriter, request []byte, timeout time.Duration, handle func(token []byte)) error {
+	_, err := terminal.Write(append(request, da1...))
+	if err != nil {
+		return err
+	}
+
+	r := newTimedReader(terminal, timeout)
+	defer r.close()
+
+	var pending []byte
+	chunk := make([]byte, 256)
+
+	for {
+		n, err := r.read(chunk)
+		pending = append(pending, chunk[:n]...)
+
+		for len(pending) != 0 {
+			advance, token, _ := sgr.ScanTokens(pending, false)
+			if advance == 0 {
+				break
+			}
+			pending = pending[advance:]
+
+			if isDeviceAttributes(token) {
+				return nil
+			}
+			handle(token)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// handleReply parses token as a reply to one of the queries and updates the first matching unanswered reply.
+func handleReply(replies []ColorReply, token []byte) {
+	body, ok := oscBody(token)
+	if !ok {
+		return
+	}
+
+	fields := bytes.Split(body, []byte(";"))
+	if len(fields) < 2 {
+		return
+	}
+
+	code, err := strconv.ParseUint(string(fields[0]), 10, 8)
+	if err != nil {
+		return
+	}
+
+	query := ColorQuery{code: uint8(code)}
+	if query.code == codePaletteColor {
+		if len(fields) != 3 {
+			return
+		}
+
+		index, err := strconv.ParseUint(string(fields[1]), 10, 8)
+		if err != nil {
+			return
+		}
+		query.index = uint8(index)
+	} else if len(fields) != 2 {
+		return
+	}
+
+	color, err := ParseColor(string(fields[len(fields)-1]))
+	if err != nil {
+		return
+	}
+
+	for i := range replies {
+		if replies[i].Query == query && !replies[i].OK {
+			replies[i].Color = color
+			replies[i].OK = true
+
+			return
+		}
+	}
+}
+
+// oscBody returns the contents of OSC sequence without the introducer and the terminator.
+func oscBody(token []byte) ([]byte, bool) {
+	if !bytes.HasPrefix(token, []byte(oscBegin)) {
+		return nil, false
+	}
+
+	switch {
+	case bytes.HasSuffix(token, []byte(oscEnd)):
+		return token[len(oscBegin) : len(token)-len(oscEnd)], true
+	case bytes.HasSuffix(token, []byte("\a")):
+		return token[len(oscBegin) : len(token)-1], true
+	default:
+		return nil, false
+	}
+}
+
+// isDeviceAttributes returns true if token is a reply to DA1 request, like "\x1b[?62;22c".
+func isDeviceAttributes(token []byte) bool {
+	return bytes.HasPrefix(token, []byte("\x1b[?")) && token[len(token)-1] == 'c'
+}
+
+// ---
+
+type deadliner interface {
+	SetReadDeadline(time.Time) error
+}
+
+// timedReader reads from r until the deadline using either SetReadDeadline method of r
+// or a separate goroutine for each read in case r does not support deadlines.
+type timedReader struct {
+	r        io.Reader
+	timeout  time.Duration
+	deadline time.Time
+	dr       deadliner
+}
+
+type readResult struct {
+	data []byte
+	err  error
+}
+
+func newTimedReader(r io.Reader, timeout time.Duration) *timedReader {
+	tr := &timedReader{r: r, timeout: timeout, deadline: time.Now().Add(timeout)}
+
+	if dr, ok := r.(deadliner); ok && dr.SetReadDeadline(tr.deadline) == nil {
+		tr.dr = dr
+	}
+
+	return tr
+}
+
+func (r *timedReader) read(buf []byte) (int, error) {
+	if r.dr != nil {
+		n, err := r.r.Read(buf)
+		if errors.Is(err, os.ErrDeadlineExceeded) {
+			err = ErrTimeout{r.timeout}
+		}
+
+		return n, err
+	}
+
+	ch := make(chan readResult, 1)
+	go func() {
+		data := make([]byte, len(buf))
+		n, err := r.r.Read(data)
+		ch <- readResult{data[:n], err}
+	}()
+
+	timer := time.NewTimer(time.Until(r.deadline))
+	defer timer.Stop()
+
+	select {
+	case result := <-ch:
+		return copy(buf, result.data), result.err
+	case <-timer.C:
+		return 0, ErrTimeout{r.timeout}
+	}
+}
+
+func (r *timedReader) close() {
+	if r.dr != nil {
+		_ = r.dr.SetReadDeadline(time.Time{})
+	}
+}
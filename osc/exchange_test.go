@@ -0,0 +1,240 @@
+package osc_test
+
+import (
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/pamburus/go-tst/tst"
+
+	"github.com/pamburus/go-ansi-esc/osc"
+	"github.com/pamburus/go-ansi-esc/sgr"
+)
+
+func TestQueryColors(tt *testing.T) {
+	t := New(tt)
+
+	queries := []osc.ColorQuery{
+		osc.ForegroundColorQuery,
+		osc.BackgroundColorQuery,
+		osc.PaletteColorQuery(1),
+		osc.CursorColorQuery,
+	}
+
+	t.Run("Replies", func(t Test) {
+		terminal := newFakeTerminal(map[string]string{
+			"\x1b]10;?\x1b\\":  "\x1b]10;rgb:cdcd/d6d6/f4f4\x1b\\",
+			"\x1b]11;?\x1b\\":  "\x1b]11;rgb:1e1e/1e1e/2e2e\a",
+			"\x1b]4;1;?\x1b\\": "\x1b]4;1;rgb:f3/8b/a8\x1b\\",
+		})
+
+		replies, err := osc.QueryColors(terminal, queries)
+		t.Expect(err).ToSucceed()
+		t.Expect(replies).ToEqual([]osc.ColorReply{
+			{Query: osc.ForegroundColorQuery, Color: sgr.RGB(0xcd, 0xd6, 0xf4), OK: true},
+			{Query: osc.BackgroundColorQuery, Color: sgr.RGB(0x1e, 0x1e, 0x2e), OK: true},
+			{Query: osc.PaletteColorQuery(1), Color: sgr.RGB(0xf3, 0x8b, 0xa8), OK: true},
+			{Query: osc.CursorColorQuery},
+		})
+		t.Expect(terminal.request()).ToEqual("\x1b]10;?\x1b\\\x1b]11;?\x1b\\\x1b]4;1;?\x1b\\\x1b]12;?\x1b\\\x1b[c")
+	})
+
+	t.Run("Order", func(t Test) {
+		terminal := newFakeTerminal(map[string]string{
+			"\x1b]10;?\x1b\\":  "\x1b]10;rgb:0000/0000/0000\x1b\\",
+			"\x1b]11;?\x1b\\":  "\x1b]11;rgb:ffff/ffff/ffff\x1b\\",
+			"\x1b]4;1;?\x1b\\": "\x1b]4;1;rgb:ffff/0000/0000\x1b\\",
+			"\x1b]12;?\x1b\\":  "\x1b]12;rgb:8080/8080/8080\x1b\\",
+		})
+		terminal.reversed = true
+		terminal.noise = "x\x1b[A\x1b]99;?\x1b\\"
+
+		replies, err := osc.QueryColors(terminal, queries)
+		t.Expect(err).ToSucceed()
+		t.Expect(replies).ToEqual([]osc.ColorReply{
+			{Query: osc.ForegroundColorQuery, Color: sgr.RGB(0, 0, 0), OK: true},
+			{Query: osc.BackgroundColorQuery, Color: sgr.RGB(255, 255, 255), OK: true},
+			{Query: osc.PaletteColorQuery(1), Color: sgr.RGB(255, 0, 0), OK: true},
+			{Query: osc.CursorColorQuery, Color: sgr.RGB(128, 128, 128), OK: true},
+		})
+	})
+
+	t.Run("Malformed", func(t Test) {
+		terminal := newFakeTerminal(map[string]string{
+			"\x1b]10;?\x1b\\":  "\x1b]10;rgb:zz/00/00\x1b\\",
+			"\x1b]11;?\x1b\\":  "\x1b]11;1;rgb:ff/ff/ff\x1b\\",
+			"\x1b]4;1;?\x1b\\": "\x1b]4;x;rgb:ff/ff/ff\x1b\\\x1b]4;1\x1b\\\x1b]4;999;rgb:ff/ff/ff\x1b\\",
+			"\x1b]12;?\x1b\\":  "\x1b]\x1b\\\x1b]x;y\x1b\\",
+		})
+
+		replies, err := osc.QueryColors(terminal, queries)
+		t.Expect(err).ToSucceed()
+		for _, reply := range replies {
+			t.Expect(reply.OK).ToBeFalse()
+		}
+	})
+
+	t.Run("Timeout", func(t Test) {
+		terminal := newFakeTerminal(nil)
+		terminal.silent = true
+
+		replies, err := osc.QueryColors(terminal, queries, osc.WithTimeout(20*time.Millisecond))
+		t.Expect(err).ToFailWith(osc.ErrTimeout{Value: 20 * time.Millisecond})
+		t.Expect(len(replies)).ToEqual(len(queries))
+	})
+
+	t.Run("Deadline", func(t Test) {
+		r, w, err := os.Pipe()
+		t.Expect(err).ToSucceed()
+		defer r.Close()
+		defer w.Close()
+
+		terminal := &pipeTerminal{r, io.Discard}
+		_, err = osc.QueryColors(terminal, queries, osc.WithTimeout(20*time.Millisecond))
+		t.Expect(err).ToFailWith(osc.ErrTimeout{Value: 20 * time.Millisecond})
+
+		_, err = w.WriteString("\x1b]11;rgb:ffff/ffff/ffff\x1b\\\x1b[?62c")
+		t.Expect(err).ToSucceed()
+		t.Expect(osc.QueryColor(terminal, osc.BackgroundColorQuery)).ToSucceed().AndResult().ToEqual(sgr.RGB(255, 255, 255))
+	})
+
+	t.Run("WriteError", func(t Test) {
+		r, w, err := os.Pipe()
+		t.Expect(err).ToSucceed()
+		defer w.Close()
+		t.Expect(r.Close()).ToSucceed()
+
+		_, err = osc.QueryColors(&pipeTerminal{r, r}, queries)
+		t.Expect(err).ToFailWith(os.ErrClosed)
+	})
+
+	t.Run("EOF", func(t Test) {
+		_, err := osc.QueryColors(&pipeTerminal{strings.NewReader("\x1b]11;rgb:ff/ff/ff\x1b\\"), io.Discard}, queries)
+		t.Expect(err).ToFailWith(io.EOF)
+	})
+}
+
+func TestQueryColor(tt *testing.T) {
+	t := New(tt)
+
+	terminal := newFakeTerminal(map[string]string{
+		"\x1b]11;?\x1b\\": "\x1b]11;rgb:1e1e/1e1e/2e2e\x1b\\",
+	})
+
+	t.Expect(osc.QueryColor(terminal, osc.BackgroundColorQuery)).ToSucceed().AndResult().ToEqual(sgr.RGB(30, 30, 46))
+	t.Expect(osc.QueryColor(terminal, osc.CursorColorQuery)).ToFailWith(osc.ErrUnsupportedQuery{Value: osc.CursorColorQuery})
+
+	terminal.silent = true
+	t.Expect(osc.QueryColor(terminal, osc.BackgroundColorQuery, osc.WithTimeout(time.Millisecond))).ToFailWith(osc.ErrTimeout{})
+}
+
+func TestQueryBackground(tt *testing.T) {
+	t := New(tt)
+
+	query := func(reply string) (sgr.Background, error) {
+		return osc.QueryBackground(newFakeTerminal(map[string]string{"\x1b]11;?\x1b\\": reply}))
+	}
+
+	t.Expect(query("\x1b]11;rgb:0000/2b2b/3636\x1b\\")).ToSucceed().AndResult().ToEqual(sgr.BackgroundDark)
+	t.Expect(query("\x1b]11;rgb:fdfd/f6f6/e3e3\x1b\\")).ToSucceed().AndResult().ToEqual(sgr.BackgroundLight)
+	t.Expect(query("")).ToFailWith(osc.ErrUnsupportedQuery{Value: osc.BackgroundColorQuery})
+}
+
+func TestQueryPalette(tt *testing.T) {
+	t := New(tt)
+
+	t.Run("Partial", func(t Test) {
+		terminal := newFakeTerminal(map[string]string{
+			"\x1b]4;0;?\x1b\\":   "\x1b]4;0;rgb:1010/1010/1010\x1b\\",
+			"\x1b]4;255;?\x1b\\": "\x1b]4;255;rgb:fefe/fefe/fefe\x1b\\",
+		})
+
+		palette, err := osc.QueryPalette(terminal)
+		t.Expect(err).ToSucceed()
+		t.Expect(palette[0]).ToEqual(sgr.RGB(16, 16, 16))
+		t.Expect(palette[1]).ToEqual(sgr.DefaultPalette[1])
+		t.Expect(palette[255]).ToEqual(sgr.RGB(254, 254, 254))
+	})
+
+	t.Run("Unsupported", func(t Test) {
+		palette, err := osc.QueryPalette(newFakeTerminal(nil))
+		t.Expect(err).ToFailWith(osc.ErrUnsupportedQuery{})
+		t.Expect(palette).ToEqual(sgr.DefaultPalette)
+	})
+}
+
+// ---
+
+// fakeTerminal is a stand-in for a pseudo-terminal that replies to known requests.
+type fakeTerminal struct {
+	replies  map[string]string
+	reversed bool
+	silent   bool
+	noise    string
+	last     string
+	r        *io.PipeReader
+	w        *io.PipeWriter
+}
+
+func newFakeTerminal(replies map[string]string) *fakeTerminal {
+	r, w := io.Pipe()
+
+	return &fakeTerminal{replies: replies, r: r, w: w}
+}
+
+func (t *fakeTerminal) Read(buf []byte) (int, error) {
+	return t.r.Read(buf)
+}
+
+func (t *fakeTerminal) Write(data []byte) (int, error) {
+	t.last = string(data)
+	if t.silent {
+		return len(data), nil
+	}
+
+	var replies []string
+	for rest := data; len(rest) != 0; {
+		n, token, _ := sgr.ScanTokens(rest, true)
+		rest = rest[n:]
+		if reply, ok := t.replies[string(token)]; ok {
+			replies = append(replies, reply)
+		}
+	}
+
+	if t.reversed {
+		for i, j := 0, len(replies)-1; i < j; i, j = i+1, j-1 {
+			replies[i], replies[j] = replies[j], replies[i]
+		}
+	}
+
+	// Deliver the reply byte by byte to make sure it is reassembled correctly.
+	reply := t.noise + strings.Join(replies, "") + "\x1b[?62;22c"
+	go func() {
+		for i := range reply {
+			_, _ = t.w.Write([]byte{reply[i]})
+		}
+	}()
+
+	return len(data), nil
+}
+
+func (t *fakeTerminal) request() string {
+	return t.last
+}
+
+// ---
+
+type pipeTerminal struct {
+	io.Reader
+	io.Writer
+}
+
+func (t *pipeTerminal) SetReadDeadline(deadline time.Time) error {
+	if f, ok := t.Reader.(*os.File); ok {
+		return f.SetReadDeadline(deadline)
+	}
+
+	return os.ErrNoDeadline
+}
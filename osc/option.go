@@ -0,0 +1,77 @@
+package osc
+
+import (
+	"time"
+)
+
+// ---
+
+// Default values of options.
+const (
+	// DefaultTimeout is the default time to wait for the terminal to complete its reply.
+	DefaultTimeout = time.Second
+	// DefaultClipboardLimit is the default maximum size of base64 encoded clipboard data.
+	// It is a bit less than the limits of most popular terminals.
+	DefaultClipboardLimit = 100000
+)
+
+// ---
+
+// Option is an option that can be passed to query and clipboard functions to customize their behavior.
+type Option func(*options)
+
+// WithTimeout returns an Option that sets the time to wait for the terminal to complete its reply.
+// Default is DefaultTimeout.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.timeout = timeout
+	}
+}
+
+// WithSelection returns an Option that sets the selections to use in clipboard operations.
+// Only the first selection is used for reading.
+// Default is SelectionClipboard.
+func WithSelection(selections ...Selection) Option {
+	return func(o *options) {
+		o.selections = selections
+	}
+}
+
+// WithClipboardLimit returns an Option that sets the maximum size of base64 encoded clipboard data.
+// Zero or negative limit means no limit.
+// Default is DefaultClipboardLimit.
+func WithClipboardLimit(limit int) Option {
+	return func(o *options) {
+		o.limit = limit
+	}
+}
+
+// WithPassthrough returns an Option that makes clipboard sequences wrapped for passing through a terminal multiplexer.
+// Default is PassthroughNone.
+func WithPassthrough(passthrough Passthrough) Option {
+	return func(o *options) {
+		o.passthrough = passthrough
+	}
+}
+
+// ---
+
+type options struct {
+	timeout     time.Duration
+	selections  []Selection
+	limit       int
+	passthrough Passthrough
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{
+		timeout:    DefaultTimeout,
+		selections: []Selection{SelectionClipboard},
+		limit:      DefaultClipboardLimit,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o
+}
@@ -0,0 +1,84 @@
+package osc
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ---
+
+// Complete set of valid Passthrough values.
+const (
+	// PassthroughNone does not wrap sequences.
+	PassthroughNone Passthrough = iota
+	// PassthroughTmux wraps sequences into tmux DCS passthrough sequence.
+	// Note that tmux requires "allow-passthrough" option to be enabled since version 3.3.
+	PassthroughTmux
+	// PassthroughScreen wraps sequences into GNU screen DCS sequences split into chunks
+	// to fit into the screen's limit for a single sequence.
+	PassthroughScreen
+)
+
+// Passthrough defines how sequences are wrapped to pass through a terminal multiplexer to the outer terminal.
+type Passthrough uint8
+
+// DetectPassthrough detects the terminal multiplexer the process is running in using TMUX, STY and TERM environment variables.
+func DetectPassthrough() Passthrough {
+	term := os.Getenv("TERM")
+
+	switch {
+	case os.Getenv("TMUX") != "", strings.HasPrefix(term, "tmux"):
+		return PassthroughTmux
+	case os.Getenv("STY") != "", strings.HasPrefix(term, "screen"):
+		return PassthroughScreen
+	default:
+		return PassthroughNone
+	}
+}
+
+// Wrap appends seq to buf wrapping it as needed to pass through the terminal multiplexer.
+func (p Passthrough) Wrap(buf, seq []byte) []byte {
+	switch p {
+	case PassthroughTmux:
+		buf = append(buf, "\x1bPtmux;"...)
+		for _, c := range seq {
+			if c == '\x1b' {
+				buf = append(buf, c)
+			}
+			buf = append(buf, c)
+		}
+
+		return append(buf, oscEnd...)
+	case PassthroughScreen:
+		for len(seq) != 0 {
+			n := min(len(seq), screenChunkSize)
+			buf = append(buf, "\x1bP"...)
+			buf = append(buf, seq[:n]...)
+			buf = append(buf, oscEnd...)
+			seq = seq[n:]
+		}
+
+		return buf
+	default:
+		return append(buf, seq...)
+	}
+}
+
+// String returns textual description of p that can be used for debugging or logging purposes.
+func (p Passthrough) String() string {
+	switch p {
+	case PassthroughNone:
+		return "none"
+	case PassthroughTmux:
+		return "tmux"
+	case PassthroughScreen:
+		return "screen"
+	default:
+		return fmt.Sprintf("<!0x%02x>", uint8(p))
+	}
+}
+
+// ---
+
+const screenChunkSize = 76
@@ -0,0 +1,59 @@
+package osc_test
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/pamburus/go-tst/tst"
+
+	"github.com/pamburus/go-ansi-esc/osc"
+)
+
+func TestPassthrough(tt *testing.T) {
+	t := New(tt)
+
+	seq := []byte("\x1b]52;c;YQ==\x1b\\")
+
+	t.Run("None", func(t Test) {
+		t.Expect(string(osc.PassthroughNone.Wrap([]byte("x"), seq))).ToEqual("x\x1b]52;c;YQ==\x1b\\")
+	})
+
+	t.Run("Tmux", func(t Test) {
+		t.Expect(string(osc.PassthroughTmux.Wrap(nil, seq))).ToEqual("\x1bPtmux;\x1b\x1b]52;c;YQ==\x1b\x1b\\\x1b\\")
+	})
+
+	t.Run("Screen", func(t Test) {
+		t.Expect(string(osc.PassthroughScreen.Wrap(nil, seq))).ToEqual("\x1bP\x1b]52;c;YQ==\x1b\\\x1b\\")
+
+		long := []byte("\x1b]52;c;" + strings.Repeat("A", 100) + "\a")
+		t.Expect(string(osc.PassthroughScreen.Wrap(nil, long))).ToEqual(
+			"\x1bP\x1b]52;c;" + strings.Repeat("A", 69) + "\x1b\\" +
+				"\x1bP" + strings.Repeat("A", 31) + "\a\x1b\\",
+		)
+	})
+
+	t.Run("String", func(t Test) {
+		t.Expect(osc.PassthroughNone.String()).ToEqual("none")
+		t.Expect(osc.PassthroughTmux.String()).ToEqual("tmux")
+		t.Expect(osc.PassthroughScreen.String()).ToEqual("screen")
+		t.Expect(osc.Passthrough(9).String()).ToEqual("<!0x09>")
+	})
+
+	t.Run("Detect", func(t Test) {
+		for _, tc := range []struct {
+			tmux, sty, term string
+			want            osc.Passthrough
+		}{
+			{"", "", "xterm-256color", osc.PassthroughNone},
+			{"/tmp/tmux-1000/default,1,0", "", "xterm-256color", osc.PassthroughTmux},
+			{"", "", "tmux-256color", osc.PassthroughTmux},
+			{"", "1234.pts-0.host", "xterm", osc.PassthroughScreen},
+			{"", "", "screen.xterm-256color", osc.PassthroughScreen},
+		} {
+			t.Setenv("TMUX", tc.tmux)
+			t.Setenv("STY", tc.sty)
+			t.Setenv("TERM", tc.term)
+			t.Expect(osc.DetectPassthrough()).ToEqual(tc.want)
+		}
+	})
+}
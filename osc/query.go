@@ -0,0 +1,86 @@
+// Package osc provides facilities for querying terminal colors and accessing the clipboard
+// using OSC (Operating System Command) ANSI Escape Sequences.
+//
+// Colors are queried using OSC 4 for palette entries and OSC 10, 11 and 12 for default foreground,
+// background and cursor colors as defined by xterm and supported by most modern terminals.
+// Each query set is followed by DA1 (Primary Device Attributes) request, that is answered by virtually all terminals,
+// so that the end of the replies is detected without waiting for the timeout even if some queries are not supported.
+//
+// Clipboard contents are set and queried using OSC 52 with base64 encoded data.
+// Inside tmux or GNU screen the sequences can be wrapped to pass through to the outer terminal, see Passthrough.
+//
+// The terminal must be in raw mode while querying, otherwise the replies are echoed and not delivered
+// until the user presses Enter. Use for example golang.org/x/term package to switch the terminal to raw mode.
+package osc
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// ---
+
+// PaletteColorQuery returns a ColorQuery for the palette entry with the given index using OSC 4.
+func PaletteColorQuery(index uint8) ColorQuery {
+	return ColorQuery{codePaletteColor, index}
+}
+
+// ---
+
+// Queries for default colors.
+var (
+	ForegroundColorQuery = ColorQuery{code: codeForegroundColor} // Default foreground color using OSC 10.
+	BackgroundColorQuery = ColorQuery{code: codeBackgroundColor} // Default background color using OSC 11.
+	CursorColorQuery     = ColorQuery{code: codeCursorColor}     // Cursor color using OSC 12.
+)
+
+// ---
+
+// ColorQuery is a query for one of terminal colors.
+type ColorQuery struct {
+	code  uint8
+	index uint8
+}
+
+// Render produces OSC sequence that requests the color terminated with ST.
+func (q ColorQuery) Render(buf []byte) []byte {
+	buf = append(buf, oscBegin...)
+	buf = strconv.AppendUint(buf, uint64(q.code), 10)
+	if q.code == codePaletteColor {
+		buf = append(buf, ';')
+		buf = strconv.AppendUint(buf, uint64(q.index), 10)
+	}
+
+	return append(buf, ";?"+oscEnd...)
+}
+
+// String returns textual description of q that can be used for debugging or logging purposes.
+func (q ColorQuery) String() string {
+	switch q.code {
+	case codePaletteColor:
+		return fmt.Sprintf("PaletteColor(%d)", q.index)
+	case codeForegroundColor:
+		return "ForegroundColor"
+	case codeBackgroundColor:
+		return "BackgroundColor"
+	case codeCursorColor:
+		return "CursorColor"
+	default:
+		return fmt.Sprintf("<!%d>", q.code)
+	}
+}
+
+// ---
+
+const (
+	codePaletteColor    = 4
+	codeForegroundColor = 10
+	codeBackgroundColor = 11
+	codeCursorColor     = 12
+)
+
+const (
+	oscBegin = "\x1b]"
+	oscEnd   = "\x1b\\"
+	da1      = "\x1b[c"
+)
@@ -0,0 +1,28 @@
+package osc_test
+
+import (
+	"testing"
+
+	. "github.com/pamburus/go-tst/tst"
+
+	"github.com/pamburus/go-ansi-esc/osc"
+)
+
+func TestColorQuery(tt *testing.T) {
+	t := New(tt)
+
+	t.Run("Render", func(t Test) {
+		t.Expect(string(osc.PaletteColorQuery(5).Render(nil))).ToEqual("\x1b]4;5;?\x1b\\")
+		t.Expect(string(osc.ForegroundColorQuery.Render([]byte("x")))).ToEqual("x\x1b]10;?\x1b\\")
+		t.Expect(string(osc.BackgroundColorQuery.Render(nil))).ToEqual("\x1b]11;?\x1b\\")
+		t.Expect(string(osc.CursorColorQuery.Render(nil))).ToEqual("\x1b]12;?\x1b\\")
+	})
+
+	t.Run("String", func(t Test) {
+		t.Expect(osc.PaletteColorQuery(255).String()).ToEqual("PaletteColor(255)")
+		t.Expect(osc.ForegroundColorQuery.String()).ToEqual("ForegroundColor")
+		t.Expect(osc.BackgroundColorQuery.String()).ToEqual("BackgroundColor")
+		t.Expect(osc.CursorColorQuery.String()).ToEqual("CursorColor")
+		t.Expect(osc.ColorQuery{}.String()).ToEqual("<!0>")
+	})
+}
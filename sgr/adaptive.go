@@ -0,0 +1,112 @@
+package sgr
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ---
+
+// Complete set of valid Background values.
+const (
+	// BackgroundDark is a dark terminal background, it is assumed by default.
+	BackgroundDark Background = iota
+	// BackgroundLight is a light terminal background.
+	BackgroundLight
+)
+
+// Background is a kind of terminal background luminance used to resolve AdaptiveColor values.
+type Background uint8
+
+// BackgroundOf returns the kind of background having the given color.
+func BackgroundOf(color RGBColor) Background {
+	if color.IsDark() {
+		return BackgroundDark
+	}
+
+	return BackgroundLight
+}
+
+// String returns textual description of b that can be used for debugging or logging purposes.
+func (b Background) String() string {
+	if name, ok := backgroundNames[b]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("<!0x%02x>", uint8(b))
+}
+
+// Validate check that b has a valid value.
+func (b Background) Validate() error {
+	if _, ok := backgroundNames[b]; !ok {
+		return ErrInvalidBackgroundValue{b}
+	}
+
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler interface
+// that allows Background to be used in any compatible marshaler like JSON, YAML, etc.
+func (b Background) MarshalText() ([]byte, error) {
+	err := b.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(b.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler interface
+// that allows Background to be used in any compatible unmarshaler like JSON, YAML, etc.
+func (b *Background) UnmarshalText(data []byte) error {
+	text := strings.TrimSpace(string(data))
+
+	for value, name := range backgroundNames {
+		if strings.EqualFold(text, name) {
+			*b = value
+
+			return nil
+		}
+	}
+
+	return ErrInvalidBackgroundText{text}
+}
+
+// ---
+
+// AdaptiveColor is a color that has different values for light and dark terminal backgrounds,
+// so that it stays readable in both cases.
+// Writer resolves it according to the background set using WithBackground option
+// when it is passed to methods like SetForegroundColor or PushForegroundColor either by value or by pointer.
+// Nil Light or Dark value resolves to zero Color.
+type AdaptiveColor struct {
+	Light IntoColor
+	Dark  IntoColor
+}
+
+// Color implements IntoColor interface and returns the color for dark background, that is assumed by default.
+// It is used where the background is not known, for example in Style values.
+func (c AdaptiveColor) Color() Color {
+	return c.Resolve(BackgroundDark)
+}
+
+// Resolve returns the color for the given background.
+func (c AdaptiveColor) Resolve(background Background) Color {
+	color := c.Dark
+	if background == BackgroundLight {
+		color = c.Light
+	}
+
+	if color == nil {
+		return 0
+	}
+
+	return color.Color()
+}
+
+// ---
+
+var backgroundNames = map[Background]string{
+	BackgroundDark:  "dark",
+	BackgroundLight: "light",
+}
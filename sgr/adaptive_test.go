@@ -0,0 +1,90 @@
+package sgr_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	. "github.com/pamburus/go-tst/tst"
+
+	"github.com/pamburus/go-ansi-esc/sgr"
+)
+
+func TestBackground(tt *testing.T) {
+	t := New(tt)
+
+	t.Run("Of", func(t Test) {
+		t.Expect(sgr.BackgroundOf(sgr.RGB(0, 43, 54))).ToEqual(sgr.BackgroundDark)
+		t.Expect(sgr.BackgroundOf(sgr.RGB(253, 246, 227))).ToEqual(sgr.BackgroundLight)
+	})
+
+	t.Run("String", func(t Test) {
+		t.Expect(sgr.BackgroundDark.String()).ToEqual("dark")
+		t.Expect(sgr.BackgroundLight.String()).ToEqual("light")
+		t.Expect(sgr.Background(7).String()).ToEqual("<!0x07>")
+	})
+
+	t.Run("Text", func(t Test) {
+		t.Expect(sgr.BackgroundLight.MarshalText()).ToSucceed().AndResult().ToEqual([]byte("light"))
+		t.Expect(sgr.Background(7).MarshalText()).ToFailWith(sgr.ErrInvalidBackgroundValue{Value: 7})
+
+		var background sgr.Background
+		t.Expect(background.UnmarshalText([]byte(" Light "))).ToSucceed()
+		t.Expect(background).ToEqual(sgr.BackgroundLight)
+		t.Expect(background.UnmarshalText([]byte("dark"))).ToSucceed()
+		t.Expect(background).ToEqual(sgr.BackgroundDark)
+		t.Expect(background.UnmarshalText([]byte("grey"))).ToFailWith(sgr.ErrInvalidBackgroundText{Value: "grey"})
+		t.Expect(json.Unmarshal([]byte(`"light"`), &background)).ToSucceed()
+		t.Expect(background).ToEqual(sgr.BackgroundLight)
+	})
+}
+
+func TestAdaptiveColor(tt *testing.T) {
+	t := New(tt)
+
+	color := sgr.AdaptiveColor{Light: sgr.Black, Dark: sgr.BrightBlack}
+
+	t.Run("Resolve", func(t Test) {
+		t.Expect(color.Color()).ToEqual(sgr.BrightBlack.Color())
+		t.Expect(color.Resolve(sgr.BackgroundDark)).ToEqual(sgr.BrightBlack.Color())
+		t.Expect(color.Resolve(sgr.BackgroundLight)).ToEqual(sgr.Black.Color())
+		t.Expect(sgr.AdaptiveColor{Dark: sgr.White}.Resolve(sgr.BackgroundLight)).ToEqual(sgr.Color(0))
+	})
+
+	t.Run("Writer", func(t Test) {
+		run := func(options ...sgr.WriterOption) string {
+			buf := bytes.NewBuffer(nil)
+			w := sgr.NewWriter(buf, options...)
+			w.PushForegroundColor(color)
+			w.PushBackgroundColor(sgr.AdaptiveColor{Light: sgr.RGB(238, 232, 213), Dark: sgr.RGB(7, 54, 66)})
+			w.PushUnderlineColor(sgr.AdaptiveColor{Light: sgr.Blue})
+			_, _ = w.WriteString("x")
+			w.PopUnderlineColor()
+			w.PopBackgroundColor()
+			w.PopForegroundColor()
+			_ = w.Flush()
+
+			return buf.String()
+		}
+
+		t.Expect(run()).ToEqual("\x1b[48;2;7;54;66;90mx\x1b[0m")
+		t.Expect(run(sgr.WithBackground(sgr.BackgroundLight))).ToEqual("\x1b[48;2;238;232;213;30;58;5;4mx\x1b[0m")
+		t.Expect(sgr.NewWriter(nil, sgr.WithBackground(sgr.BackgroundLight)).Background()).ToEqual(sgr.BackgroundLight)
+
+		buf := bytes.NewBuffer(nil)
+		w := sgr.NewWriter(buf, sgr.WithBackground(sgr.BackgroundLight))
+		w.SetForegroundColor(color)
+		_, _ = w.WriteString("x")
+		w.SetBackgroundColor(sgr.AdaptiveColor{Light: sgr.White, Dark: sgr.Black})
+		_, _ = w.WriteString("y")
+		t.Expect(buf.String()).ToEqual("\x1b[30mx\x1b[47my")
+
+		buf.Reset()
+		w.SetForegroundColor(sgr.Red)
+		w.PushForegroundColor(&color)
+		_, _ = w.WriteString("z")
+		t.Expect(buf.String()).ToEqual("z")
+		t.Expect(w.Adapt(color)).ToEqual(sgr.Black.Color())
+		t.Expect(sgr.NewWriter(nil).Adapt(color)).ToEqual(sgr.BrightBlack.Color())
+	})
+}
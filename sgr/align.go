@@ -0,0 +1,195 @@
+package sgr
+
+import (
+	"bytes"
+
+	"github.com/rivo/uniseg"
+)
+
+// ---
+
+// Truncate returns b truncated to fit into the given number of terminal cells with ellipsis appended in place of the removed text.
+//
+// Truncation never cuts inside an escape sequence or a grapheme cluster.
+// Escape sequences of the kept part are preserved and escape sequences of the removed part are dropped.
+// Styles of the ellipsis are applied on top of the style at the cut point, so that an ellipsis with zero style inherits it.
+// If the style at the end of the result is not the terminal default style, a sequence resetting it is appended.
+// Likewise, if a hyperlink opened in the kept part is not closed there, it is closed after the ellipsis.
+// If the ellipsis itself does not fit, it is truncated without an ellipsis.
+// If b already fits, it is returned as is.
+func Truncate(b []byte, cols int, ellipsis StyledText) []byte {
+	if Width(b) <= cols {
+		return b
+	}
+
+	ellipsis = ellipsis.Truncate(cols, StyledText{})
+	limit := cols - ellipsis.Width()
+
+	result := make([]byte, 0, len(b)+len(ellipsis.text)+16)
+	var interp Interpreter
+	width := 0
+	linked := false
+
+	for len(b) != 0 {
+		n, token, _ := ScanTokens(b, true)
+		if IsEscape(token) {
+			if IsSequence(token) {
+				seq, _ := ParseSequence(token)
+				interp.Apply(seq)
+			} else if open, ok := isHyperlinkOpen(token); ok {
+				linked = open
+			}
+			result = append(result, token...)
+			b = b[n:]
+
+			continue
+		}
+
+		cluster, _, w, _ := uniseg.FirstGraphemeCluster(token, -1)
+		if width+w > limit {
+			break
+		}
+
+		result = append(result, cluster...)
+		width += w
+		b = b[len(cluster):]
+	}
+
+	st := interp.st.normalized()
+	seq := make(Sequence, 0, 8)
+	start := 0
+	for _, run := range ellipsis.runs {
+		next := run.style.apply(interp.st).normalized()
+		result = OptimizeIncremental.transition(seq[:0], st, next).Render(result)
+		result = append(result, ellipsis.text[start:run.end]...)
+		st = next
+		start = run.end
+	}
+
+	if st != defaultState {
+		result = Sequence{ResetAll}.Render(result)
+	}
+
+	if linked {
+		result = Hyperlink{}.Render(result)
+	}
+
+	return result
+}
+
+// PadRight returns b with spaces appended to make it occupy at least the given number of terminal cells.
+func PadRight(b []byte, cols int) []byte {
+	return pad(b, 0, cols-Width(b))
+}
+
+// PadLeft returns b with spaces prepended to make it occupy at least the given number of terminal cells.
+func PadLeft(b []byte, cols int) []byte {
+	return pad(b, cols-Width(b), 0)
+}
+
+// Center returns b with spaces added on both sides to make it occupy at least the given number of terminal cells.
+// In case the number of added spaces is odd, the extra space is added on the right side.
+func Center(b []byte, cols int) []byte {
+	n := cols - Width(b)
+
+	return pad(b, n/2, n-n/2)
+}
+
+// ---
+
+// Width returns the number of terminal cells needed to display the plain text of t.
+func (t StyledText) Width() int {
+	return Width([]byte(t.text))
+}
+
+// Truncate returns a new StyledText truncated to fit into the given number of terminal cells
+// with ellipsis appended in place of the removed text.
+// Truncation never cuts inside a grapheme cluster.
+// Styles of the ellipsis are applied on top of the style of the first removed character.
+// If the ellipsis itself does not fit, it is truncated without an ellipsis.
+// If t already fits, it is returned as is.
+func (t StyledText) Truncate(cols int, ellipsis StyledText) StyledText {
+	if t.Width() <= cols {
+		return t
+	}
+
+	if !ellipsis.IsEmpty() {
+		ellipsis = ellipsis.Truncate(cols, StyledText{})
+	}
+	limit := cols - ellipsis.Width()
+
+	text := t.text
+	width := 0
+	for text != "" {
+		_, rest, w, _ := uniseg.FirstGraphemeClusterInString(text, -1)
+		if width+w > limit {
+			break
+		}
+		width += w
+		text = rest
+	}
+
+	cut := len(t.text) - len(text)
+
+	var b styledTextBuilder
+	b.appendSlice(t, 0, cut)
+	b.appendTextOver(ellipsis, t.StyleAt(cut))
+
+	return b.result()
+}
+
+// PadRight returns a new StyledText with unstyled spaces appended to make it occupy at least the given number of terminal cells.
+func (t StyledText) PadRight(cols int) StyledText {
+	return t.pad(0, cols-t.Width())
+}
+
+// PadLeft returns a new StyledText with unstyled spaces prepended to make it occupy at least the given number of terminal cells.
+func (t StyledText) PadLeft(cols int) StyledText {
+	return t.pad(cols-t.Width(), 0)
+}
+
+// Center returns a new StyledText with unstyled spaces added on both sides to make it occupy at least the given number of terminal cells.
+// In case the number of added spaces is odd, the extra space is added on the right side.
+func (t StyledText) Center(cols int) StyledText {
+	n := cols - t.Width()
+
+	return t.pad(n/2, n-n/2)
+}
+
+func (t StyledText) pad(left, right int) StyledText {
+	if left <= 0 && right <= 0 {
+		return t
+	}
+
+	var b styledTextBuilder
+	b.append(spaces(left), Style{})
+	b.appendText(t)
+	b.append(spaces(right), Style{})
+
+	return b.result()
+}
+
+// ---
+
+func pad(b []byte, left, right int) []byte {
+	if left <= 0 && right <= 0 {
+		return b
+	}
+
+	left = max(left, 0)
+	right = max(right, 0)
+
+	result := make([]byte, 0, len(b)+left+right)
+	result = append(result, bytes.Repeat([]byte{' '}, left)...)
+	result = append(result, b...)
+
+	return append(result, bytes.Repeat([]byte{' '}, right)...)
+}
+
+func spaces(n int) string {
+	if n <= 0 {
+		return ""
+	}
+
+	return string(bytes.Repeat([]byte{' '}, n))
+}
@@ -0,0 +1,62 @@
+package sgr_test
+
+import (
+	"testing"
+
+	. "github.com/pamburus/go-tst/tst"
+
+	"github.com/pamburus/go-ansi-esc/sgr"
+)
+
+func TestAlign(tt *testing.T) {
+	t := New(tt)
+
+	dots := sgr.NewStyledText("…", sgr.Style{})
+	faintDots := sgr.NewStyledText("…", sgr.Style{Modes: sgr.Faint.ModeSet()})
+
+	t.Run("Truncate", func(t Test) {
+		truncate := func(s string, cols int, ellipsis sgr.StyledText) string {
+			return string(sgr.Truncate([]byte(s), cols, ellipsis))
+		}
+
+		t.Expect(truncate("hello", 5, dots)).ToEqual("hello")
+		t.Expect(truncate("hello world", 5, dots)).ToEqual("hell…")
+		t.Expect(truncate("hello world", 5, sgr.StyledText{})).ToEqual("hello")
+		t.Expect(truncate("\x1b[31mhello\x1b[0m world", 5, dots)).ToEqual("\x1b[31mhell…\x1b[0m")
+		t.Expect(truncate("\x1b[31mhel\x1b[1mlo\x1b[0m world", 5, faintDots)).ToEqual("\x1b[31mhel\x1b[1ml\x1b[2m…\x1b[0m")
+		t.Expect(truncate("ab\x1b[32mcdef\x1b[0m", 3, faintDots)).ToEqual("ab\x1b[32m\x1b[2m…\x1b[0m")
+		t.Expect(truncate("\x1b]8;;x\x1b\\link\x1b]8;;\x1b\\ text", 4, dots)).ToEqual("\x1b]8;;x\x1b\\lin…\x1b]8;;\x1b\\")
+		t.Expect(truncate("\x1b]8;id=1;x\a\x1b[1mlink text", 4, dots)).ToEqual("\x1b]8;id=1;x\a\x1b[1mlin…\x1b[0m\x1b]8;;\x1b\\")
+		t.Expect(truncate("\x1b]8;;x\x1b\\li\x1b]8;;\x1b\\nk text", 4, dots)).ToEqual("\x1b]8;;x\x1b\\li\x1b]8;;\x1b\\n…")
+		t.Expect(truncate("日本語", 4, dots)).ToEqual("日…")
+		t.Expect(truncate("日本語", 3, sgr.StyledText{})).ToEqual("日")
+		t.Expect(truncate("ééé", 2, sgr.StyledText{})).ToEqual("éé")
+		t.Expect(truncate("hello", 1, sgr.NewStyledText("...", sgr.Style{}))).ToEqual(".")
+		t.Expect(truncate("hello", 0, dots)).ToEqual("")
+	})
+
+	t.Run("Pad", func(t Test) {
+		t.Expect(string(sgr.PadRight([]byte("\x1b[1m日\x1b[0m"), 4))).ToEqual("\x1b[1m日\x1b[0m  ")
+		t.Expect(string(sgr.PadLeft([]byte("\x1b[1ma\x1b[0m"), 3))).ToEqual("  \x1b[1ma\x1b[0m")
+		t.Expect(string(sgr.Center([]byte("ab"), 5))).ToEqual(" ab  ")
+		t.Expect(string(sgr.PadRight([]byte("abc"), 2))).ToEqual("abc")
+		t.Expect(string(sgr.Center([]byte("abc"), 3))).ToEqual("abc")
+	})
+
+	t.Run("StyledText", func(t Test) {
+		red := sgr.Style{Foreground: sgr.Red.Color()}
+		bold := sgr.Style{Modes: sgr.Bold.ModeSet()}
+		text := sgr.NewStyledText("hel", red).Append("lo wörld", bold)
+
+		t.Expect(text.Width()).ToEqual(11)
+		t.Expect(text.Truncate(11, dots)).ToEqual(text)
+		t.Expect(text.Truncate(5, faintDots)).ToEqual(
+			sgr.NewStyledText("hel", red).Append("l", bold).Append("…", bold.WithOther(faintDots.StyleAt(0))),
+		)
+		t.Expect(text.Truncate(3, dots)).ToEqual(sgr.NewStyledText("he…", red))
+		t.Expect(text.Slice(0, 3).PadLeft(5)).ToEqual(sgr.NewStyledText("  ", sgr.Style{}).Append("hel", red))
+		t.Expect(text.Slice(0, 3).PadRight(4)).ToEqual(sgr.NewStyledText("hel", red).Append(" ", sgr.Style{}))
+		t.Expect(text.Slice(0, 3).Center(6)).ToEqual(sgr.NewStyledText(" ", sgr.Style{}).Append("hel", red).Append("  ", sgr.Style{}))
+		t.Expect(text.Center(3)).ToEqual(text)
+	})
+}
@@ -324,6 +324,12 @@ func (c RGBColor) Color() Color {
 	return Color(c) | colorKindRGB
 }
 
+// IsDark returns true if c is perceived as a dark color, so that light text is better readable on it.
+// It uses perceived brightness formula from ITU-R BT.601.
+func (c RGBColor) IsDark() bool {
+	return 299*int(c.R())+587*int(c.G())+114*int(c.B()) <= 128000
+}
+
 // String returns textual description of c that can be used for debugging or logging purposes.
 func (c RGBColor) String() string {
 	return fmt.Sprintf("#%06x", uint32(c))
@@ -353,6 +353,15 @@ func TestColor(tt *testing.T) {
 			t.Expect(sgr.RGB(15, 30, 45).Validate()).ToSucceed()
 		})
 
+		t.Run("IsDark", func(t Test) {
+			t.Expect(sgr.RGB(0, 0, 0).IsDark()).ToBeTrue()
+			t.Expect(sgr.RGB(30, 30, 46).IsDark()).ToBeTrue()
+			t.Expect(sgr.RGB(0, 0, 255).IsDark()).ToBeTrue()
+			t.Expect(sgr.RGB(255, 255, 255).IsDark()).ToBeFalse()
+			t.Expect(sgr.RGB(253, 246, 227).IsDark()).ToBeFalse()
+			t.Expect(sgr.RGB(0, 255, 0).IsDark()).ToBeFalse()
+		})
+
 		t.Run("MarshalText", func(t Test) {
 			text, err := sgr.RGB(16, 32, 48).MarshalText()
 			t.Expect(err).ToNot(HaveOccurred())
@@ -91,6 +91,14 @@ const (
 	SetBackgroundColorBrightWhite   = commandValid | Command(CodeSetBackgroundColorBrightWhite)
 )
 
+// Underline style commands using sub-parameters of SetUnderlined command, like "4:3" for curly underline.
+// They are not supported by all terminals, those that do not support them usually show a single underline.
+const (
+	SetCurlyUnderlined  = SetUnderlined | commandSubParams | commandArgCount1 | Command(3)<<8
+	SetDottedUnderlined = SetUnderlined | commandSubParams | commandArgCount1 | Command(4)<<8
+	SetDashedUnderlined = SetUnderlined | commandSubParams | commandArgCount1 | Command(5)<<8
+)
+
 // Command is an SGR command that can be used to control terminal's SGR (Select Graphic Rendition) parameters.
 type Command uint64
 
@@ -103,6 +111,10 @@ func (c Command) String() string {
 		return fmt.Sprintf("<!0x%08x>", uint64(c))
 	}
 
+	if name, ok := underlineStyleCommandNames[c]; ok {
+		return name
+	}
+
 	code := CommandCode(c & 0xFF)
 	switch code {
 	case CodeSetBackgroundColor, CodeSetForegroundColor, CodeSetUnderlineColor:
@@ -130,14 +142,32 @@ func (c Command) render(buf []byte) []byte {
 
 	buf = append(buf, btoa(byte(c.Code()))...)
 
+	sep := byte(';')
+	if c&commandSubParams != 0 {
+		sep = ':'
+	}
+
 	for i := 0; i != c.argCount(); i++ {
-		buf = append(buf, ';')
+		buf = append(buf, sep)
 		buf = append(buf, btoa(c.arg(i))...)
 	}
 
 	return buf
 }
 
+func (c Command) renderedLen() int {
+	if !c.valid() {
+		return 0
+	}
+
+	n := len(btoa(byte(c.Code())))
+	for i := 0; i != c.argCount(); i++ {
+		n += 1 + len(btoa(c.arg(i)))
+	}
+
+	return n
+}
+
 func (c Command) valid() bool {
 	return (c & commandValid) != 0
 }
@@ -235,6 +265,7 @@ func (c CommandCode) String() string {
 // ---
 
 var commandNames = map[CommandCode]string{
+	CodeResetAll:                        "ResetAll",
 	CodeSetBold:                         "SetBold",
 	CodeSetFaint:                        "SetFaint",
 	CodeSetItalic:                       "SetItalic",
@@ -397,6 +428,7 @@ func cmdArg(i int, val uint8) Command {
 
 const (
 	commandValid         = 0x1000000000000000
+	commandSubParams     = 0x2000000000000000
 	commandArgCountMask  = 0x0F00000000000000
 	commandArgCount1     = 0x0100000000000000
 	commandArgCount2     = 0x0200000000000000
@@ -404,3 +436,11 @@ const (
 	commandArgCount4     = 0x0400000000000000
 	commandArgCountShift = 64 - 8
 )
+
+// ---
+
+var underlineStyleCommandNames = map[Command]string{
+	SetCurlyUnderlined:  "SetCurlyUnderlined",
+	SetDottedUnderlined: "SetDottedUnderlined",
+	SetDashedUnderlined: "SetDashedUnderlined",
+}
@@ -43,6 +43,14 @@ func TestCommand(tt *testing.T) {
 		})
 	})
 
+	t.Run("UnderlineStyle", func(t Test) {
+		t.Expect(sgr.SetCurlyUnderlined.String()).ToEqual("SetCurlyUnderlined")
+		t.Expect(sgr.SetDottedUnderlined.String()).ToEqual("SetDottedUnderlined")
+		t.Expect(sgr.SetDashedUnderlined.String()).ToEqual("SetDashedUnderlined")
+		t.Expect(sgr.SetCurlyUnderlined.Code()).ToEqual(sgr.CodeSetUnderlined)
+		t.Expect(string(sgr.Sequence{sgr.SetBold, sgr.SetCurlyUnderlined, sgr.SetUnderlineColor(sgr.Red)}.Bytes())).ToEqual("\x1b[1;4:3;58;5;1m")
+	})
+
 	t.Run("SetBackgroundColor", func(t Test) {
 		t.Expect(
 			sgr.SetBackgroundColor(sgr.BrightCyan).String(),
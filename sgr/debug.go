@@ -0,0 +1,239 @@
+package sgr
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ---
+
+// FormatDebug converts data containing escape sequences to a human-readable annotated form
+// that is convenient for golden tests and debugging.
+//
+// Each SGR sequence is replaced with its commands enclosed in "⟨" and "⟩" brackets, for example
+// "⟨bg:#0a0a1e fg:BrightGreen +Underlined⟩f1⟨fg:BrightBlack -Underlined⟩:", where
+//   - "fg:", "bg:" and "ul:" prefixes followed by a color text representation set colors,
+//     with "Default" color text resetting the color to the terminal default;
+//   - "+" and "-" prefixes followed by a mode name set and reset modes,
+//     commands resetting a pair of modes are represented using the first mode of the pair, like "-Bold" for "22",
+//     and underline styles have their own modes, like "+CurlyUnderlined" for "4:3";
+//   - "reset" resets everything to terminal defaults;
+//   - any other command is represented using Command.String, like "<!10>".
+//
+// SGR sequences that are not in the canonical form produced by Sequence.Render, like "\x1b[m",
+// and all other escape sequences are represented as ASCII-only double-quoted Go string literals enclosed in brackets.
+// The "⟨" character in text is doubled.
+// So ParseDebug always converts the result back to exactly the same bytes.
+func FormatDebug(data []byte) string {
+	var sb strings.Builder
+	sb.Grow(len(data) * 2)
+
+	for len(data) != 0 {
+		n, token, _ := ScanTokens(data, true)
+		data = data[n:]
+
+		if !IsEscape(token) {
+			sb.WriteString(strings.ReplaceAll(string(token), debugOpen, debugOpen+debugOpen))
+
+			continue
+		}
+
+		sb.WriteString(debugOpen)
+
+		if seq, ok := parseDebugSequence(token); ok {
+			for i, c := range seq {
+				if i != 0 {
+					sb.WriteByte(' ')
+				}
+				sb.WriteString(c.debugString())
+			}
+		} else {
+			sb.WriteString(strconv.QuoteToASCII(string(token)))
+		}
+
+		sb.WriteString(debugClose)
+	}
+
+	return sb.String()
+}
+
+// ParseDebug converts text in the form produced by FormatDebug back to data containing escape sequences.
+// See FormatDebug for the details.
+func ParseDebug(text string) ([]byte, error) {
+	result := make([]byte, 0, len(text))
+
+	for text != "" {
+		i := strings.Index(text, debugOpen)
+		if i < 0 {
+			result = append(result, text...)
+
+			break
+		}
+
+		result = append(result, text[:i]...)
+		text = text[i+len(debugOpen):]
+
+		if strings.HasPrefix(text, debugOpen) {
+			result = append(result, debugOpen...)
+			text = text[len(debugOpen):]
+
+			continue
+		}
+
+		if strings.HasPrefix(text, `"`) {
+			quoted, err := strconv.QuotedPrefix(text)
+			if err != nil {
+				return nil, ErrInvalidDebugText{text}
+			}
+
+			raw, _ := strconv.Unquote(quoted)
+			text = text[len(quoted):]
+			if !strings.HasPrefix(text, debugClose) {
+				return nil, ErrInvalidDebugText{text}
+			}
+
+			result = append(result, raw...)
+			text = text[len(debugClose):]
+
+			continue
+		}
+
+		end := strings.Index(text, debugClose)
+		if end < 0 {
+			return nil, ErrInvalidDebugText{text}
+		}
+
+		seq := make(Sequence, 0, 4)
+		for _, word := range strings.Fields(text[:end]) {
+			c, ok := parseDebugCommand(word)
+			if !ok {
+				return nil, ErrInvalidDebugText{word}
+			}
+			seq = append(seq, c)
+		}
+
+		if len(seq) == 0 {
+			return nil, ErrInvalidDebugText{text[:end]}
+		}
+
+		result = seq.Render(result)
+		text = text[end+len(debugClose):]
+	}
+
+	return result, nil
+}
+
+// ---
+
+func (c Command) debugString() string {
+	code := c.Code()
+
+	if mode, ok := styledUnderlineCommandModes[c]; ok {
+		return "+" + mode.String()
+	}
+
+	if mode, ok := commandModes[code]; ok {
+		return "+" + mode.String()
+	}
+
+	if modes, ok := commandResetModes[code]; ok {
+		return "-" + modes.ModeList()[0].String()
+	}
+
+	switch {
+	case code == CodeResetAll:
+		return debugReset
+	case code == CodeSetForegroundColor, code == CodeResetForegroundColor,
+		code >= CodeSetForegroundColorBlack && code <= CodeSetForegroundColorWhite,
+		code >= CodeSetForegroundColorBrightBlack && code <= CodeSetForegroundColorBrightWhite:
+		return debugForeground + defaultState.withCommand(c).fgc.String()
+	case code == CodeSetBackgroundColor, code == CodeResetBackgroundColor,
+		code >= CodeSetBackgroundColorBlack && code <= CodeSetBackgroundColorWhite,
+		code >= CodeSetBackgroundColorBrightBlack && code <= CodeSetBackgroundColorBrightWhite:
+		return debugBackground + defaultState.withCommand(c).bgc.String()
+	case code == CodeSetUnderlineColor, code == CodeResetUnderlineColor:
+		return debugUnderline + defaultState.withCommand(c).ulc.String()
+	default:
+		return c.String()
+	}
+}
+
+func parseDebugCommand(word string) (Command, bool) {
+	parseColor := func(text string) (Color, bool) {
+		var c Color
+		if c.UnmarshalText([]byte(text)) != nil || c.IsZero() {
+			return 0, false
+		}
+
+		return c, true
+	}
+
+	switch {
+	case word == debugReset:
+		return ResetAll, true
+	case strings.HasPrefix(word, debugForeground):
+		c, ok := parseColor(word[len(debugForeground):])
+
+		return setForegroundColor(c), ok
+	case strings.HasPrefix(word, debugBackground):
+		c, ok := parseColor(word[len(debugBackground):])
+
+		return setBackgroundColor(c), ok
+	case strings.HasPrefix(word, debugUnderline):
+		c, ok := parseColor(word[len(debugUnderline):])
+
+		return setUnderlineColor(c), ok
+	case strings.HasPrefix(word, "+"), strings.HasPrefix(word, "-"):
+		var mode Mode
+		if mode.unmarshalText(word[1:]) != nil {
+			return 0, false
+		}
+
+		if word[0] == '+' {
+			return ModeSetDiff{New: mode.ModeSet()}.ToCommands(nil)[0], true
+		}
+
+		return ModeSetDiff{Old: mode.ModeSet()}.ToCommands(nil)[0], true
+	case strings.HasPrefix(word, "<!") && strings.HasSuffix(word, ">"):
+		code, err := strconv.ParseUint(word[2:len(word)-1], 10, 8)
+		if err != nil {
+			return 0, false
+		}
+
+		return commandValid | Command(code), true
+	default:
+		return 0, false
+	}
+}
+
+// parseDebugSequence parses token as an SGR sequence and returns false
+// if it is not an SGR sequence or it cannot be represented in the annotated form without loss.
+func parseDebugSequence(token []byte) (Sequence, bool) {
+	if !IsSequence(token) {
+		return nil, false
+	}
+
+	seq, err := ParseSequence(token)
+	if err != nil || len(seq) == 0 {
+		return nil, false
+	}
+
+	for _, c := range seq {
+		if !c.valid() {
+			return nil, false
+		}
+	}
+
+	return seq, string(seq.Render(nil)) == string(token)
+}
+
+// ---
+
+const (
+	debugOpen       = "⟨"
+	debugClose      = "⟩"
+	debugReset      = "reset"
+	debugForeground = "fg:"
+	debugBackground = "bg:"
+	debugUnderline  = "ul:"
+)
@@ -0,0 +1,67 @@
+package sgr_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	. "github.com/pamburus/go-tst/tst"
+
+	"github.com/pamburus/go-ansi-esc/sgr"
+)
+
+func TestDebug(tt *testing.T) {
+	t := New(tt)
+
+	t.Run("Format", func(t Test) {
+		cases := []struct {
+			data string
+			text string
+		}{
+			{"", ""},
+			{"plain", "plain"},
+			{"\x1b[48;2;10;10;30;92;4mf1\x1b[90;24m:", "⟨bg:#0a0a1e fg:BrightGreen +Underlined⟩f1⟨fg:BrightBlack -Underlined⟩:"},
+			{"\x1b[38;5;1;49;58;5;200;39;59mx\x1b[0m", "⟨fg:#01 bg:Default ul:#c8 fg:Default ul:Default⟩x⟨reset⟩"},
+			{"\x1b[22;2;25;6;10m", "⟨-Bold +Faint -SlowBlink +RapidBlink <!10>⟩"},
+			{"\x1b[4:3ma\x1b[4:4mb\x1b[4:5mc\x1b[24m", "⟨+CurlyUnderlined⟩a⟨+DottedUnderlined⟩b⟨+DashedUnderlined⟩c⟨-Underlined⟩"},
+			{"\x1b[mx\x1b[38:5:1m", `⟨"\x1b[m"⟩x⟨"\x1b[38:5:1m"⟩`},
+			{"\x1b]8;;http://x/ y\x1b\\⟨⟩\x1b[", `⟨"\x1b]8;;http://x/ y\x1b\\"⟩⟨⟨⟩⟨"\x1b["⟩`},
+		}
+
+		for _, tc := range cases {
+			t.Expect(sgr.FormatDebug([]byte(tc.data))).ToEqual(tc.text)
+			t.Expect(sgr.ParseDebug(tc.text)).ToSucceed().AndResult().ToEqual([]byte(tc.data))
+		}
+	})
+
+	t.Run("Parse", func(t Test) {
+		t.Expect(sgr.ParseDebug("⟨ +bold  -faint fg:red bg:#ff0000 -DoublyUnderlined ⟩x")).ToSucceed().AndResult().
+			ToEqual([]byte("\x1b[1;22;31;48;2;255;0;0;24mx"))
+
+		for _, text := range []string{"⟨⟩", "⟨bold⟩", "⟨fg:nope⟩", "⟨+Nope⟩", "⟨fg:red", `⟨"\x1b`, `⟨"x"`, "⟨<!1000>⟩"} {
+			t.Expect(sgr.ParseDebug(text)).ToFailWith(sgr.ErrInvalidDebugText{})
+		}
+	})
+
+	t.Run("Record", func(t Test) {
+		rec := record{
+			ts:      time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC),
+			logger:  []byte("tst"),
+			message: []byte("hello"),
+			fields: []field{
+				{[]byte("f1"), "v1"},
+				{[]byte("f2"), []field{{[]byte("f3"), 20}}},
+			},
+		}
+
+		buf := bytes.NewBuffer(nil)
+		t.Expect(rec.Write(buf)).ToSucceed()
+		t.Expect(sgr.FormatDebug(buf.Bytes())).ToEqual(
+			"⟨fg:BrightBlack⟩2023-06-01T12:00:00Z⟨reset⟩ |⟨fg:Cyan⟩INF⟨reset⟩| ⟨fg:BrightBlack⟩tst:⟨reset⟩ " +
+				"⟨fg:BrightWhite⟩hello⟨reset⟩ " +
+				"⟨bg:#0a0a1e fg:BrightGreen +Underlined⟩f1⟨fg:BrightBlack -Underlined⟩:⟨fg:BrightCyan⟩v1⟨reset⟩ " +
+				"⟨bg:#0a0a1e fg:BrightGreen +Underlined⟩f2⟨fg:BrightBlack -Underlined⟩:{ " +
+				"⟨fg:BrightGreen +Underlined⟩f3⟨fg:BrightBlack -Underlined⟩:⟨fg:BrightBlue⟩20⟨fg:BrightBlack⟩ }⟨reset⟩\n",
+		)
+	})
+}
@@ -0,0 +1,96 @@
+package sgr
+
+import (
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// ---
+
+// DetectColorProfile detects the color profile supported by the terminal the given writer is connected to
+// using well-known environment variables.
+//
+// The rules are applied in the following order:
+//   - NO_COLOR set to a non-empty value disables colors;
+//   - FORCE_COLOR set to "0" or "false" disables colors, "1" or "true" forces ColorProfile16,
+//     "2" forces ColorProfile256 and "3" forces ColorProfileTrueColor regardless of the writer;
+//   - writers other than an *os.File connected to a character device have no colors;
+//   - TERM set to "dumb" or not set disables colors;
+//   - COLORTERM set to "truecolor" or "24bit" enables ColorProfileTrueColor;
+//   - TERM containing "truecolor" or "direct" enables ColorProfileTrueColor and containing "256color" enables ColorProfile256;
+//   - otherwise ColorProfile16 is used.
+func DetectColorProfile(w io.Writer) ColorProfile {
+	if os.Getenv("NO_COLOR") != "" {
+		return ColorProfileNone
+	}
+
+	switch strings.ToLower(os.Getenv("FORCE_COLOR")) {
+	case "":
+	case "0", "false":
+		return ColorProfileNone
+	case "2":
+		return ColorProfile256
+	case "3":
+		return ColorProfileTrueColor
+	default:
+		return ColorProfile16
+	}
+
+	if !IsTerminal(w) {
+		return ColorProfileNone
+	}
+
+	term := os.Getenv("TERM")
+
+	switch {
+	case term == "" || term == "dumb":
+		return ColorProfileNone
+	case strings.EqualFold(os.Getenv("COLORTERM"), "truecolor"), strings.EqualFold(os.Getenv("COLORTERM"), "24bit"):
+		return ColorProfileTrueColor
+	case strings.Contains(term, "truecolor"), strings.Contains(term, "direct"):
+		return ColorProfileTrueColor
+	case strings.Contains(term, "256color"):
+		return ColorProfile256
+	default:
+		return ColorProfile16
+	}
+}
+
+// DetectBackground detects whether the terminal background is dark or light using COLORFGBG environment variable
+// that is set by some terminals like rxvt and Konsole in form of "foreground;background" or "foreground;other;background".
+// Background palette indexes 0 to 6 and 8 are considered dark and 7 and 9 to 15 light.
+// It returns false if the variable is not set or the background cannot be determined from it.
+// See also osc.QueryBackground for querying the actual background color from the terminal.
+func DetectBackground() (Background, bool) {
+	value := os.Getenv("COLORFGBG")
+	if value == "" {
+		return BackgroundDark, false
+	}
+
+	index, err := strconv.Atoi(value[strings.LastIndexByte(value, ';')+1:])
+	switch {
+	case err != nil, index < 0, index > 15:
+		return BackgroundDark, false
+	case index < 7, index == 8:
+		return BackgroundDark, true
+	default:
+		return BackgroundLight, true
+	}
+}
+
+// IsTerminal returns true if w is an *os.File connected to a character device like a terminal.
+func IsTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+
+	return info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,78 @@
+package sgr_test
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	. "github.com/pamburus/go-tst/tst"
+
+	"github.com/pamburus/go-ansi-esc/sgr"
+)
+
+func TestDetectColorProfile(tt *testing.T) {
+	t := New(tt)
+
+	setenv := func(t Test, env map[string]string) {
+		for _, key := range []string{"NO_COLOR", "FORCE_COLOR", "TERM", "COLORTERM"} {
+			t.Setenv(key, env[key])
+		}
+	}
+
+	buf := bytes.NewBuffer(nil)
+
+	t.Run("NotTerminal", func(t Test) {
+		setenv(t, map[string]string{"TERM": "xterm-256color", "COLORTERM": "truecolor"})
+		t.Expect(sgr.DetectColorProfile(buf)).ToEqual(sgr.ColorProfileNone)
+		t.Expect(sgr.IsTerminal(buf)).ToEqual(false)
+
+		f, err := os.CreateTemp(t.TempDir(), "")
+		t.Expect(err).ToSucceed()
+		defer f.Close()
+		t.Expect(sgr.DetectColorProfile(f)).ToEqual(sgr.ColorProfileNone)
+	})
+
+	t.Run("Force", func(t Test) {
+		for value, profile := range map[string]sgr.ColorProfile{
+			"0":     sgr.ColorProfileNone,
+			"false": sgr.ColorProfileNone,
+			"1":     sgr.ColorProfile16,
+			"true":  sgr.ColorProfile16,
+			"2":     sgr.ColorProfile256,
+			"3":     sgr.ColorProfileTrueColor,
+		} {
+			setenv(t, map[string]string{"FORCE_COLOR": value})
+			t.Expect(sgr.DetectColorProfile(buf)).ToEqual(profile)
+		}
+	})
+
+	t.Run("NoColor", func(t Test) {
+		setenv(t, map[string]string{"NO_COLOR": "1", "FORCE_COLOR": "3"})
+		t.Expect(sgr.DetectColorProfile(buf)).ToEqual(sgr.ColorProfileNone)
+	})
+}
+
+func TestDetectBackground(tt *testing.T) {
+	t := New(tt)
+
+	for value, want := range map[string]struct {
+		background sgr.Background
+		ok         bool
+	}{
+		"":             {sgr.BackgroundDark, false},
+		"15;0":         {sgr.BackgroundDark, true},
+		"0;15":         {sgr.BackgroundLight, true},
+		"0;7":          {sgr.BackgroundLight, true},
+		"7;8":          {sgr.BackgroundDark, true},
+		"12;default;0": {sgr.BackgroundDark, true},
+		"0;default;11": {sgr.BackgroundLight, true},
+		"15;default":   {sgr.BackgroundDark, false},
+		"0;16":         {sgr.BackgroundDark, false},
+		"0;-1":         {sgr.BackgroundDark, false},
+	} {
+		t.Setenv("COLORFGBG", value)
+		background, ok := sgr.DetectBackground()
+		t.Expect(background).ToEqual(want.background)
+		t.Expect(ok).ToEqual(want.ok)
+	}
+}
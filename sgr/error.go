@@ -254,3 +254,212 @@ func (e ErrInvalidModeText) Is(err error) bool {
 
 	return false
 }
+
+// ---
+
+// ErrInvalidColorProfileValue is an error that occurs in case explicit validation or marshaling discovers an invalid value.
+type ErrInvalidColorProfileValue struct {
+	Value ColorProfile
+}
+
+// Error returns the error message.
+func (e ErrInvalidColorProfileValue) Error() string {
+	return fmt.Sprintf("invalid color profile value %d", e.Value)
+}
+
+// Is returns true if e is a sub-class of err.
+func (e ErrInvalidColorProfileValue) Is(err error) bool {
+	if other, ok := err.(ErrInvalidColorProfileValue); ok {
+		return other.Value == 0 || other.Value == e.Value
+	}
+
+	return false
+}
+
+// ---
+
+// ErrInvalidColorProfileText is an error that occurs in case of parsing an invalid textual representation of ColorProfile.
+type ErrInvalidColorProfileText struct {
+	Value string
+}
+
+// Error returns the error message.
+func (e ErrInvalidColorProfileText) Error() string {
+	return fmt.Sprintf("invalid color profile text %q", e.Value)
+}
+
+// Is returns true if e is a sub-class of err.
+func (e ErrInvalidColorProfileText) Is(err error) bool {
+	if other, ok := err.(ErrInvalidColorProfileText); ok {
+		return other.Value == "" || other.Value == e.Value
+	}
+
+	return false
+}
+
+// ---
+
+// ErrInvalidBackgroundValue is an error that occurs in case explicit validation or marshaling discovers an invalid value.
+type ErrInvalidBackgroundValue struct {
+	Value Background
+}
+
+// Error returns the error message.
+func (e ErrInvalidBackgroundValue) Error() string {
+	return fmt.Sprintf("invalid background value %d", e.Value)
+}
+
+// Is returns true if e is a sub-class of err.
+func (e ErrInvalidBackgroundValue) Is(err error) bool {
+	if other, ok := err.(ErrInvalidBackgroundValue); ok {
+		return other.Value == 0 || other.Value == e.Value
+	}
+
+	return false
+}
+
+// ---
+
+// ErrInvalidBackgroundText is an error that occurs in case of parsing an invalid textual representation of Background.
+type ErrInvalidBackgroundText struct {
+	Value string
+}
+
+// Error returns the error message.
+func (e ErrInvalidBackgroundText) Error() string {
+	return fmt.Sprintf("invalid background text %q", e.Value)
+}
+
+// Is returns true if e is a sub-class of err.
+func (e ErrInvalidBackgroundText) Is(err error) bool {
+	if other, ok := err.(ErrInvalidBackgroundText); ok {
+		return other.Value == "" || other.Value == e.Value
+	}
+
+	return false
+}
+
+// ---
+
+// ErrInvalidSequence is an error that occurs in case of parsing an invalid CSI/SGR sequence.
+type ErrInvalidSequence struct {
+	Value string
+}
+
+// Error returns the error message.
+func (e ErrInvalidSequence) Error() string {
+	return fmt.Sprintf("invalid sequence %q", e.Value)
+}
+
+// Is returns true if e is a sub-class of err.
+func (e ErrInvalidSequence) Is(err error) bool {
+	if other, ok := err.(ErrInvalidSequence); ok {
+		return other.Value == "" || other.Value == e.Value
+	}
+
+	return false
+}
+
+// ---
+
+// ErrInvalidStyleText is an error that occurs in case of parsing an invalid textual style representation.
+type ErrInvalidStyleText struct {
+	Value   string
+	details error
+}
+
+// Error returns the error message.
+func (e ErrInvalidStyleText) Error() string {
+	if e.details != nil {
+		return fmt.Sprintf("invalid style text %q: %v", e.Value, e.details)
+	}
+
+	return fmt.Sprintf("invalid style text %q", e.Value)
+}
+
+// Unwrap returns the underlying error.
+func (e ErrInvalidStyleText) Unwrap() error {
+	return e.details
+}
+
+// Is returns true if e is a sub-class of err.
+func (e ErrInvalidStyleText) Is(err error) bool {
+	if other, ok := err.(ErrInvalidStyleText); ok {
+		return other.Value == "" || other.Value == e.Value
+	}
+
+	return false
+}
+
+// ---
+
+// ErrInvalidMarkup is an error that occurs in case of parsing a markup text having an invalid or unbalanced tag.
+type ErrInvalidMarkup struct {
+	Value   string
+	details error
+}
+
+// Error returns the error message.
+func (e ErrInvalidMarkup) Error() string {
+	if e.details != nil {
+		return fmt.Sprintf("invalid markup tag %q: %v", e.Value, e.details)
+	}
+
+	return fmt.Sprintf("invalid markup tag %q", e.Value)
+}
+
+// Unwrap returns the underlying error.
+func (e ErrInvalidMarkup) Unwrap() error {
+	return e.details
+}
+
+// Is returns true if e is a sub-class of err.
+func (e ErrInvalidMarkup) Is(err error) bool {
+	if other, ok := err.(ErrInvalidMarkup); ok {
+		return other.Value == "" || other.Value == e.Value
+	}
+
+	return false
+}
+
+// ---
+
+// ErrInvalidDebugText is an error that occurs in case of parsing an invalid annotated text produced by FormatDebug.
+type ErrInvalidDebugText struct {
+	Value string
+}
+
+// Error returns the error message.
+func (e ErrInvalidDebugText) Error() string {
+	return fmt.Sprintf("invalid debug text %q", e.Value)
+}
+
+// Is returns true if e is a sub-class of err.
+func (e ErrInvalidDebugText) Is(err error) bool {
+	if other, ok := err.(ErrInvalidDebugText); ok {
+		return other.Value == "" || other.Value == e.Value
+	}
+
+	return false
+}
+
+// ---
+
+// ErrInvalidOptimizationValue is an error that occurs in case explicit validation discovers an invalid value.
+type ErrInvalidOptimizationValue struct {
+	Value Optimization
+}
+
+// Error returns the error message.
+func (e ErrInvalidOptimizationValue) Error() string {
+	return fmt.Sprintf("invalid optimization value %d", e.Value)
+}
+
+// Is returns true if e is a sub-class of err.
+func (e ErrInvalidOptimizationValue) Is(err error) bool {
+	if other, ok := err.(ErrInvalidOptimizationValue); ok {
+		return other.Value == 0 || other.Value == e.Value
+	}
+
+	return false
+}
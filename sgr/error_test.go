@@ -45,6 +45,25 @@ func TestError(tt *testing.T) {
 	t.Expect(sgr.ErrInvalidModeText{}).To(MatchError(sgr.ErrInvalidModeText{}))
 	t.Expect(sgr.ErrInvalidModeValue{}).ToNot(MatchError(sgr.ErrInvalidModeText{}))
 	t.Expect(sgr.ErrInvalidModeText{}).ToNot(MatchError(sgr.ErrInvalidModeValue{}))
+	t.Expect(sgr.ErrInvalidColorProfileValue{}.Error()).ToNotEqual("")
+	t.Expect(sgr.ErrInvalidColorProfileText{}.Error()).ToNotEqual("")
+	t.Expect(sgr.ErrInvalidBackgroundValue{}.Error()).ToNotEqual("")
+	t.Expect(sgr.ErrInvalidBackgroundText{}.Error()).ToNotEqual("")
+	t.Expect(sgr.ErrInvalidBackgroundValue{Value: 5}).To(MatchError(sgr.ErrInvalidBackgroundValue{}))
+	t.Expect(sgr.ErrInvalidBackgroundText{"text"}).To(MatchError(sgr.ErrInvalidBackgroundText{}))
+	t.Expect(sgr.ErrInvalidBackgroundText{}).ToNot(MatchError(sgr.ErrInvalidBackgroundValue{}))
+	t.Expect(sgr.ErrInvalidOptimizationValue{}.Error()).ToNotEqual("")
+	t.Expect(sgr.ErrInvalidOptimizationValue{Value: 7}).To(MatchError(sgr.ErrInvalidOptimizationValue{}))
+	t.Expect(sgr.ErrInvalidSequence{}.Error()).ToNotEqual("")
+	t.Expect(sgr.ErrInvalidStyleText{}.Error()).ToNotEqual("")
+	t.Expect(sgr.ErrInvalidMarkup{}.Error()).ToNotEqual("")
+	t.Expect(sgr.ErrInvalidDebugText{}.Error()).ToNotEqual("")
+	t.Expect(sgr.ErrInvalidDebugText{"text"}).To(MatchError(sgr.ErrInvalidDebugText{}))
+	t.Expect(sgr.ErrInvalidSequence{"text"}).To(MatchError(sgr.ErrInvalidSequence{}))
+	t.Expect(sgr.ErrInvalidStyleText{Value: "text"}).To(MatchError(sgr.ErrInvalidStyleText{}))
+	t.Expect(sgr.ErrInvalidStyleText{}).ToNot(MatchError(sgr.ErrInvalidMarkup{}))
+	t.Expect(sgr.ErrInvalidMarkup{Value: "text"}).To(MatchError(sgr.ErrInvalidMarkup{}))
+	t.Expect(sgr.ErrInvalidMarkup{}).ToNot(MatchError(sgr.ErrInvalidStyleText{}))
 
 	t.Expect(errors.Is(sgr.ErrInvalidColorText{}, errors.New("some"))).ToEqual(false)
 	t.Expect(errors.Is(sgr.ErrInvalidBasicColorText{}, errors.New("some"))).ToEqual(false)
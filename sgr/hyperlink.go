@@ -0,0 +1,81 @@
+package sgr
+
+import (
+	"bytes"
+	"strings"
+)
+
+// ---
+
+// Hyperlink is a hyperlink that can be attached to written text using OSC 8 escape sequences.
+// Zero value means no hyperlink.
+type Hyperlink struct {
+	// URI is the target of the hyperlink, like "https://example.com" or "file://host/path".
+	URI string
+	// ID is an optional identifier that makes terminal treat separately written pieces of text
+	// having the same URI and ID as a single hyperlink, for example for highlighting on hover.
+	ID string
+}
+
+// IsZero returns true if the hyperlink has empty URI that means no hyperlink.
+func (l Hyperlink) IsZero() bool {
+	return l.URI == ""
+}
+
+// Render produces OSC 8 sequence that opens the hyperlink or closes the current one if l is zero.
+// Control characters are removed from the URI and the ID, as well as ':' and ';' from the ID,
+// so that the result is always a single well-formed sequence.
+func (l Hyperlink) Render(buf []byte) []byte {
+	if l.IsZero() {
+		return append(buf, oscHyperlinkBegin+";"+oscEnd...)
+	}
+
+	buf = append(buf, oscHyperlinkBegin...)
+	if l.ID != "" {
+		buf = append(buf, "id="...)
+		buf = appendSanitized(buf, l.ID, ":;")
+	}
+	buf = append(buf, ';')
+	buf = appendSanitized(buf, l.URI, "")
+
+	return append(buf, oscEnd...)
+}
+
+// ---
+
+// isHyperlinkOpen reports whether token is an OSC 8 sequence and whether it opens a hyperlink rather than closes it.
+func isHyperlinkOpen(token []byte) (open, ok bool) {
+	if !bytes.HasPrefix(token, []byte(oscHyperlinkBegin)) {
+		return false, false
+	}
+
+	body := token[len(oscHyperlinkBegin):]
+	switch {
+	case bytes.HasSuffix(body, []byte(oscEnd)):
+		body = body[:len(body)-len(oscEnd)]
+	case bytes.HasSuffix(body, []byte("\a")):
+		body = body[:len(body)-1]
+	}
+
+	i := bytes.IndexByte(body, ';')
+
+	return i >= 0 && i+1 != len(body), true
+}
+
+func appendSanitized(buf []byte, s string, excluded string) []byte {
+	for i := 0; i != len(s); i++ {
+		c := s[i]
+		if c >= 0x20 && c != 0x7F && strings.IndexByte(excluded, c) < 0 {
+			buf = append(buf, c)
+		}
+	}
+
+	return buf
+}
+
+// ---
+
+const (
+	oscHyperlinkBegin = "\x1b]8;"
+	oscEnd            = "\x1b\\"
+)
@@ -0,0 +1,30 @@
+package sgr_test
+
+import (
+	"testing"
+
+	. "github.com/pamburus/go-tst/tst"
+
+	"github.com/pamburus/go-ansi-esc/sgr"
+)
+
+func TestHyperlink(tt *testing.T) {
+	t := New(tt)
+
+	t.Run("Render", func(t Test) {
+		t.Expect(string(sgr.Hyperlink{}.Render(nil))).ToEqual("\x1b]8;;\x1b\\")
+		t.Expect(string(sgr.Hyperlink{URI: "https://example.com"}.Render(nil))).ToEqual("\x1b]8;;https://example.com\x1b\\")
+		t.Expect(string(sgr.Hyperlink{URI: "file:///a.go", ID: "f1"}.Render([]byte("x")))).ToEqual("x\x1b]8;id=f1;file:///a.go\x1b\\")
+	})
+
+	t.Run("Sanitize", func(t Test) {
+		link := sgr.Hyperlink{URI: "http://a/\x1b\\b;c\x07", ID: "x:y;z\n"}
+		t.Expect(string(link.Render(nil))).ToEqual("\x1b]8;id=xyz;http://a/\\b;c\x1b\\")
+	})
+
+	t.Run("IsZero", func(t Test) {
+		t.Expect(sgr.Hyperlink{}.IsZero()).ToBeTrue()
+		t.Expect(sgr.Hyperlink{ID: "x"}.IsZero()).ToBeTrue()
+		t.Expect(sgr.Hyperlink{URI: "x"}.IsZero()).ToBeFalse()
+	})
+}
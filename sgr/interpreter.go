@@ -0,0 +1,146 @@
+package sgr
+
+// ---
+
+// Interpreter keeps track of SGR state by applying SGR commands to it the same way a terminal does.
+// Zero value is ready to use and represents terminal defaults.
+type Interpreter struct {
+	st state
+}
+
+// Style returns current state as a Style that has all colors set to non-zero values.
+func (i *Interpreter) Style() Style {
+	return i.st.normalized().style()
+}
+
+// Reset resets current state to terminal defaults.
+func (i *Interpreter) Reset() {
+	i.st = defaultState
+}
+
+// Apply applies all commands of seq to the current state.
+func (i *Interpreter) Apply(seq Sequence) {
+	for _, c := range seq {
+		i.ApplyCommand(c)
+	}
+}
+
+// ApplyCommand applies a single command to the current state.
+// Invalid commands and commands with unknown codes are ignored.
+func (i *Interpreter) ApplyCommand(c Command) {
+	i.st = i.st.withCommand(c)
+}
+
+// ---
+
+func (s state) withCommand(c Command) state {
+	if !c.valid() {
+		return s
+	}
+
+	code := c.Code()
+
+	if mode, ok := styledUnderlineCommandModes[c]; ok {
+		s.modes = s.modes&^allUnderlineModes | mode.ModeSet()
+
+		return s
+	}
+
+	if mode, ok := commandModes[code]; ok {
+		if mode == Underlined || mode == DoublyUnderlined {
+			s.modes &^= styledUnderlineModes
+		}
+		s.modes = s.modes.With(mode)
+
+		return s
+	}
+
+	if modes, ok := commandResetModes[code]; ok {
+		s.modes &^= modes
+
+		return s
+	}
+
+	switch {
+	case code == CodeResetAll:
+		s = defaultState
+	case code >= CodeSetForegroundColorBlack && code <= CodeSetForegroundColorWhite:
+		s.fgc = BasicColor(code - CodeSetForegroundColorBlack).Color()
+	case code >= CodeSetForegroundColorBrightBlack && code <= CodeSetForegroundColorBrightWhite:
+		s.fgc = BasicColor(code - CodeSetForegroundColorBrightBlack).Bright().Color()
+	case code >= CodeSetBackgroundColorBlack && code <= CodeSetBackgroundColorWhite:
+		s.bgc = BasicColor(code - CodeSetBackgroundColorBlack).Color()
+	case code >= CodeSetBackgroundColorBrightBlack && code <= CodeSetBackgroundColorBrightWhite:
+		s.bgc = BasicColor(code - CodeSetBackgroundColorBrightBlack).Bright().Color()
+	case code == CodeSetForegroundColor:
+		s.fgc = commandToColor(c).OrDefault()
+	case code == CodeSetBackgroundColor:
+		s.bgc = commandToColor(c).OrDefault()
+	case code == CodeSetUnderlineColor:
+		s.ulc = commandToColor(c).OrDefault()
+	case code == CodeResetForegroundColor:
+		s.fgc = Default.Color()
+	case code == CodeResetBackgroundColor:
+		s.bgc = Default.Color()
+	case code == CodeResetUnderlineColor:
+		s.ulc = Default.Color()
+	}
+
+	return s
+}
+
+func (s state) normalized() state {
+	s.bgc = s.bgc.OrDefault()
+	s.fgc = s.fgc.OrDefault()
+	s.ulc = s.ulc.OrDefault()
+
+	return s
+}
+
+func (s state) style() Style {
+	return Style{
+		Background:     s.bgc,
+		Foreground:     s.fgc,
+		UnderlineColor: s.ulc,
+		Modes:          s.modes,
+	}
+}
+
+// ---
+
+var commandModes = map[CommandCode]Mode{
+	CodeSetBold:             Bold,
+	CodeSetFaint:            Faint,
+	CodeSetItalic:           Italic,
+	CodeSetUnderlined:       Underlined,
+	CodeSetSlowBlink:        SlowBlink,
+	CodeSetRapidBlink:       RapidBlink,
+	CodeSetReversed:         Reversed,
+	CodeSetConcealed:        Concealed,
+	CodeSetCrossedOut:       CrossedOut,
+	CodeSetDoublyUnderlined: DoublyUnderlined,
+	CodeSetFramed:           Framed,
+	CodeSetEncircled:        Encircled,
+	CodeSetOverlined:        Overlined,
+	CodeSetSuperscript:      Superscript,
+	CodeSetSubscript:        Subscript,
+}
+
+var styledUnderlineCommandModes = map[Command]Mode{
+	SetCurlyUnderlined:  CurlyUnderlined,
+	SetDottedUnderlined: DottedUnderlined,
+	SetDashedUnderlined: DashedUnderlined,
+}
+
+var commandResetModes = map[CommandCode]ModeSet{
+	CodeResetBoldAndFaint:            ModeSetWith(Bold, Faint),
+	CodeResetItalic:                  ModeSetWith(Italic),
+	CodeResetAllUnderlines:           allUnderlineModes,
+	CodeResetAllBlinks:               ModeSetWith(SlowBlink, RapidBlink),
+	CodeResetReversed:                ModeSetWith(Reversed),
+	CodeResetConcealed:               ModeSetWith(Concealed),
+	CodeResetCrossedOut:              ModeSetWith(CrossedOut),
+	CodeResetFramedAndEncircled:      ModeSetWith(Framed, Encircled),
+	CodeResetOverlined:               ModeSetWith(Overlined),
+	CodeResetSuperscriptAndSubscript: ModeSetWith(Superscript, Subscript),
+}
@@ -0,0 +1,89 @@
+package sgr_test
+
+import (
+	"testing"
+
+	. "github.com/pamburus/go-tst/tst"
+
+	"github.com/pamburus/go-ansi-esc/sgr"
+)
+
+func TestInterpreter(tt *testing.T) {
+	t := New(tt)
+
+	defaultStyle := sgr.Style{
+		Background:     sgr.Default.Color(),
+		Foreground:     sgr.Default.Color(),
+		UnderlineColor: sgr.Default.Color(),
+	}
+
+	t.Run("Zero", func(t Test) {
+		var i sgr.Interpreter
+		t.Expect(i.Style()).ToEqual(defaultStyle)
+	})
+
+	t.Run("Apply", func(t Test) {
+		var i sgr.Interpreter
+		i.Apply(sgr.Sequence{
+			sgr.SetBackgroundColorBrightBlue,
+			sgr.SetForegroundColorRed,
+			sgr.SetUnderlineColor(sgr.RGB(1, 2, 3)),
+			sgr.SetBold,
+			sgr.SetDoublyUnderlined,
+			sgr.SetOverlined,
+			sgr.Command(0),
+		})
+		t.Expect(i.Style()).ToEqual(sgr.Style{
+			Background:     sgr.BrightBlue.Color(),
+			Foreground:     sgr.Red.Color(),
+			UnderlineColor: sgr.RGB(1, 2, 3).Color(),
+			Modes:          sgr.ModeSetWith(sgr.Bold, sgr.DoublyUnderlined, sgr.Overlined),
+		})
+
+		i.Apply(sgr.Sequence{
+			sgr.SetBackgroundColorBlack,
+			sgr.SetForegroundColorBrightWhite,
+			sgr.ResetUnderlineColor,
+			sgr.ResetAllUnderlines,
+			sgr.SetFaint,
+		})
+		t.Expect(i.Style()).ToEqual(sgr.Style{
+			Background:     sgr.Black.Color(),
+			Foreground:     sgr.BrightWhite.Color(),
+			UnderlineColor: sgr.Default.Color(),
+			Modes:          sgr.ModeSetWith(sgr.Bold, sgr.Faint, sgr.Overlined),
+		})
+
+		i.Apply(sgr.Sequence{
+			sgr.SetBackgroundColor(sgr.PaletteColor(100)),
+			sgr.ResetForegroundColor,
+			sgr.ResetBoldAndFaint,
+		})
+		t.Expect(i.Style()).ToEqual(sgr.Style{
+			Background:     sgr.PaletteColor(100).Color(),
+			Foreground:     sgr.Default.Color(),
+			UnderlineColor: sgr.Default.Color(),
+			Modes:          sgr.Overlined.ModeSet(),
+		})
+
+		i.Apply(sgr.Sequence{sgr.ResetBackgroundColor, sgr.ResetOverlined})
+		t.Expect(i.Style()).ToEqual(defaultStyle)
+
+		i.Apply(sgr.Sequence{sgr.SetItalic, sgr.SetForegroundColorCyan})
+		i.Apply(sgr.Sequence{sgr.ResetAll})
+		t.Expect(i.Style()).ToEqual(defaultStyle)
+
+		i.Apply(sgr.Sequence{sgr.SetUnderlined, sgr.SetDoublyUnderlined, sgr.SetCurlyUnderlined})
+		t.Expect(i.Style().Modes).ToEqual(sgr.CurlyUnderlined.ModeSet())
+		i.ApplyCommand(sgr.SetDottedUnderlined)
+		t.Expect(i.Style().Modes).ToEqual(sgr.DottedUnderlined.ModeSet())
+		i.ApplyCommand(sgr.SetDoublyUnderlined)
+		t.Expect(i.Style().Modes).ToEqual(sgr.DoublyUnderlined.ModeSet())
+		i.Apply(sgr.Sequence{sgr.SetDashedUnderlined, sgr.ResetAllUnderlines})
+		t.Expect(i.Style()).ToEqual(defaultStyle)
+
+		i.ApplyCommand(sgr.SetReversed)
+		i.Reset()
+		t.Expect(i.Style()).ToEqual(defaultStyle)
+	})
+}
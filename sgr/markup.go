@@ -0,0 +1,229 @@
+package sgr
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ---
+
+// ParseMarkup parses markup text into a StyledText.
+//
+// Markup is a text with inline style tags:
+//   - "[style]" opens a tag, where style is any text accepted by ParseStyle, e.g. "[bold red on blue]";
+//   - "[/]" closes the most recently opened tag;
+//   - "[/style]" closes the most recently opened tag and requires it to have the same style text;
+//   - "[[" is an escaped literal "[" character.
+//
+// Tags can be nested, in this case styles of inner tags are applied on top of styles of outer tags.
+// Tags that remain open at the end of the text are closed implicitly.
+func ParseMarkup(markup string) (StyledText, error) {
+	items, err := parseMarkup(markup)
+	if err != nil {
+		return StyledText{}, err
+	}
+
+	var b styledTextBuilder
+	stack := []Style{{}}
+
+	for _, item := range items {
+		switch item.kind {
+		case markupText:
+			b.append(item.text, stack[len(stack)-1])
+		case markupPush:
+			stack = append(stack, stack[len(stack)-1].WithOther(item.style))
+		case markupPop:
+			stack = stack[:len(stack)-1]
+		}
+	}
+
+	return b.result(), nil
+}
+
+// ParseMarkupf formats according to a format specifier and parses the result as markup.
+// Formatted arguments are escaped so that they are never interpreted as markup tags.
+func ParseMarkupf(format string, args ...any) (StyledText, error) {
+	return ParseMarkup(fmt.Sprintf(format, markupArgs(args)...))
+}
+
+// EscapeMarkup escapes all "[" characters in text so that it is not interpreted as markup.
+func EscapeMarkup(text string) string {
+	return strings.ReplaceAll(text, "[", "[[")
+}
+
+// ---
+
+// PrintMarkup writes markup text opening and closing its tags using PushStyle and PopStyle.
+// See ParseMarkup for the markup syntax.
+// If the markup text is invalid, nothing is written.
+// It returns the number of bytes written excluding SGR sequences.
+func (w *Writer) PrintMarkup(markup string) (int, error) {
+	items, err := parseMarkup(markup)
+	if err != nil {
+		return 0, err
+	}
+
+	n := 0
+	depth := 0
+
+	defer func() {
+		for ; depth != 0; depth-- {
+			w.PopStyle()
+		}
+	}()
+
+	for _, item := range items {
+		switch item.kind {
+		case markupText:
+			m, err := w.WriteString(item.text)
+			n += m
+			if err != nil {
+				return n, err
+			}
+		case markupPush:
+			w.PushStyle(item.style)
+			depth++
+		case markupPop:
+			w.PopStyle()
+			depth--
+		}
+	}
+
+	return n, nil
+}
+
+// PrintfMarkup formats according to a format specifier and writes the result as markup using PrintMarkup.
+// Formatted arguments are escaped so that they are never interpreted as markup tags.
+func (w *Writer) PrintfMarkup(format string, args ...any) (int, error) {
+	return w.PrintMarkup(fmt.Sprintf(format, markupArgs(args)...))
+}
+
+// ---
+
+type markupItemKind uint8
+
+const (
+	markupText markupItemKind = iota
+	markupPush
+	markupPop
+)
+
+type markupItem struct {
+	kind  markupItemKind
+	text  string
+	style Style
+}
+
+func parseMarkup(markup string) ([]markupItem, error) {
+	var items []markupItem
+	var tags []string
+	var text strings.Builder
+
+	flush := func() {
+		if text.Len() != 0 {
+			items = append(items, markupItem{kind: markupText, text: text.String()})
+			text.Reset()
+		}
+	}
+
+	for len(markup) != 0 {
+		i := strings.IndexByte(markup, '[')
+		if i < 0 {
+			text.WriteString(markup)
+
+			break
+		}
+
+		text.WriteString(markup[:i])
+		markup = markup[i:]
+
+		if strings.HasPrefix(markup, "[[") {
+			text.WriteByte('[')
+			markup = markup[2:]
+
+			continue
+		}
+
+		end := strings.IndexByte(markup, ']')
+		if end < 0 {
+			return nil, ErrInvalidMarkup{markup, errUnterminatedMarkupTag}
+		}
+
+		tag := markup[1:end]
+		markup = markup[end+1:]
+		flush()
+
+		if closing, ok := strings.CutPrefix(tag, "/"); ok {
+			if len(tags) == 0 {
+				return nil, ErrInvalidMarkup{tag, errUnexpectedMarkupClosingTag}
+			}
+
+			if name := normalizeMarkupTag(closing); name != "" && name != tags[len(tags)-1] {
+				return nil, ErrInvalidMarkup{tag, errMismatchedMarkupClosingTag}
+			}
+
+			tags = tags[:len(tags)-1]
+			items = append(items, markupItem{kind: markupPop})
+
+			continue
+		}
+
+		if strings.TrimSpace(tag) == "" {
+			return nil, ErrInvalidMarkup{tag, errEmptyMarkupTag}
+		}
+
+		style, err := ParseStyle(tag)
+		if err != nil {
+			return nil, ErrInvalidMarkup{tag, err}
+		}
+
+		tags = append(tags, normalizeMarkupTag(tag))
+		items = append(items, markupItem{kind: markupPush, style: style})
+	}
+
+	flush()
+
+	for range tags {
+		items = append(items, markupItem{kind: markupPop})
+	}
+
+	return items, nil
+}
+
+func normalizeMarkupTag(tag string) string {
+	return strings.ToLower(strings.Join(strings.Fields(tag), " "))
+}
+
+func markupArgs(args []any) []any {
+	result := make([]any, len(args))
+	for i, arg := range args {
+		if _, ok := arg.(int); ok {
+			result[i] = arg
+		} else {
+			result[i] = markupArg{arg}
+		}
+	}
+
+	return result
+}
+
+// ---
+
+type markupArg struct {
+	value any
+}
+
+func (a markupArg) Format(f fmt.State, verb rune) {
+	_, _ = io.WriteString(f, EscapeMarkup(fmt.Sprintf(fmt.FormatString(f, verb), a.value)))
+}
+
+// ---
+
+var (
+	errUnterminatedMarkupTag      = errors.New("unterminated tag")
+	errUnexpectedMarkupClosingTag = errors.New("unexpected closing tag")
+	errMismatchedMarkupClosingTag = errors.New("closing tag does not match the opening tag")
+	errEmptyMarkupTag             = errors.New("empty tag")
+)
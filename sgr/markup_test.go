@@ -0,0 +1,65 @@
+package sgr_test
+
+import (
+	"bytes"
+	"testing"
+
+	. "github.com/pamburus/go-tst/tst"
+
+	"github.com/pamburus/go-ansi-esc/sgr"
+)
+
+func TestMarkup(tt *testing.T) {
+	t := New(tt)
+
+	bold := sgr.Style{Modes: sgr.Bold.ModeSet()}
+	boldRed := sgr.Style{Foreground: sgr.Red.Color(), Modes: sgr.Bold.ModeSet()}
+	underlined := sgr.Style{Modes: sgr.Underlined.ModeSet()}
+
+	t.Run("Parse", func(t Test) {
+		text := t.Expect(sgr.ParseMarkup("[bold red]Error:[/] file [u]a[[1][/u] not found")).ToSucceed().AndResult()
+		text.ToEqual(sgr.NewStyledText("Error:", boldRed).Append(" file ", sgr.Style{}).Append("a[1]", underlined).Append(" not found", sgr.Style{}))
+	})
+
+	t.Run("Nesting", func(t Test) {
+		text := t.Expect(sgr.ParseMarkup("[b]a[on blue]b[RED]c[/red]d[/ON  Blue]e[/b]f")).ToSucceed().AndResult()
+		text.ToEqual(sgr.NewStyledText("a", bold).
+			Append("b", bold.WithOther(sgr.Style{Background: sgr.Blue.Color()})).
+			Append("c", bold.WithOther(sgr.Style{Background: sgr.Blue.Color(), Foreground: sgr.Red.Color()})).
+			Append("d", bold.WithOther(sgr.Style{Background: sgr.Blue.Color()})).
+			Append("e", bold).
+			Append("f", sgr.Style{}))
+	})
+
+	t.Run("Unclosed", func(t Test) {
+		t.Expect(sgr.ParseMarkup("[b]a[i]b")).ToSucceed().AndResult().ToEqual(
+			sgr.NewStyledText("a", bold).Append("b", bold.WithOther(sgr.Style{Modes: sgr.Italic.ModeSet()})),
+		)
+	})
+
+	t.Run("Errors", func(t Test) {
+		t.Expect(sgr.ParseMarkup("a[/]")).ToFailWith(sgr.ErrInvalidMarkup{Value: "/"})
+		t.Expect(sgr.ParseMarkup("[b]a[/i]")).ToFailWith(sgr.ErrInvalidMarkup{Value: "/i"})
+		t.Expect(sgr.ParseMarkup("[b")).ToFailWith(sgr.ErrInvalidMarkup{Value: "[b"})
+		t.Expect(sgr.ParseMarkup("[]")).ToFailWith(sgr.ErrInvalidMarkup{Value: ""})
+		t.Expect(sgr.ParseMarkup("[INFO] started")).ToFailWith(sgr.ErrInvalidStyleText{Value: "INFO"})
+	})
+
+	t.Run("Format", func(t Test) {
+		text := t.Expect(sgr.ParseMarkupf("[b]%s[/] [u]%*s[/]", "[x]", 4, "y")).ToSucceed().AndResult()
+		text.ToEqual(sgr.NewStyledText("[x]", bold).Append(" ", sgr.Style{}).Append("   y", underlined))
+		t.Expect(sgr.EscapeMarkup("[b]x[/b]")).ToEqual("[[b]x[[/b]")
+	})
+
+	t.Run("Writer", func(t Test) {
+		buf := bytes.NewBuffer(nil)
+		w := sgr.NewWriter(buf)
+		w.PushForegroundColor(sgr.Green)
+		t.Expect(w.PrintfMarkup("[bold red]Error:[/] file [u]%s[/u] [i]!", "a[b]")).ToSucceed().AndResult().ToEqual(18)
+		t.Expect(w.WriteString(".")).ToSucceed()
+		t.Expect(w.PrintMarkup("[b]x[/i]")).ToFailWith(sgr.ErrInvalidMarkup{})
+		w.PopForegroundColor()
+		t.Expect(w.Flush()).ToSucceed()
+		t.Expect(buf.String()).ToEqual("\x1b[31;1mError:\x1b[32;22m file \x1b[4ma[b]\x1b[24m \x1b[3m!\x1b[23m.\x1b[0m")
+	})
+}
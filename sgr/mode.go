@@ -26,6 +26,9 @@ const (
 	Overlined
 	Superscript
 	Subscript
+	CurlyUnderlined
+	DottedUnderlined
+	DashedUnderlined
 )
 
 // ---
@@ -118,7 +121,7 @@ func ModeSetWith(modes ...Mode) ModeSet {
 // ---
 
 // ModeSet is a bit mask containing values for all Mode values.
-type ModeSet uint16
+type ModeSet uint32
 
 // IsZero returns true if s is empty.
 func (s ModeSet) IsZero() bool {
@@ -176,8 +179,8 @@ func (s ModeSet) Diff(other ModeSet) ModeSetDiff {
 
 // ModeList converts ModeSet to ModeList.
 func (s ModeSet) ModeList() ModeList {
-	result := make(ModeList, 0, 16)
-	for i := 0; i != 16; i++ {
+	result := make(ModeList, 0, 32)
+	for i := 0; i != 32; i++ {
 		if s&(1<<i) != 0 {
 			result = append(result, Mode(i))
 		}
@@ -278,6 +281,43 @@ func (d ModeSetDiff) ToCommands(seq Sequence) Sequence {
 		}
 	}
 
+	if changed&allUnderlineModes != 0 {
+		seq = appendUnderlineCommands(seq, d.Old&allUnderlineModes, d.New&allUnderlineModes)
+	}
+
+	return seq
+}
+
+// appendUnderlineCommands appends commands needed to bring old underline modes to new underline modes.
+// Curly, dotted and dashed underlines replace any other underline in terminals,
+// so they are set without resetting the others and the others are set again when they are removed.
+func appendUnderlineCommands(seq Sequence, old, new ModeSet) Sequence {
+	if styled := new & styledUnderlineModes; styled != 0 {
+		for _, row := range styledUnderlineCommands {
+			if styled.Has(row.mode) {
+				seq = append(seq, row.command)
+			}
+		}
+
+		return seq
+	}
+
+	if old&styledUnderlineModes != 0 {
+		if new == 0 {
+			return append(seq, ResetAllUnderlines)
+		}
+		old = 0
+	}
+
+	oi := int(old) >> int(Underlined)
+	ni := int(new) >> int(Underlined)
+	cmdMask := &modeSyncDualCommandMask[oi][ni]
+	for i, cmd := range underlineSyncCommands {
+		if cmdMask[i] != 0 {
+			seq = append(seq, cmd)
+		}
+	}
+
 	return seq
 }
 
@@ -374,17 +414,28 @@ var modeSyncTableDual = []struct {
 			SetSubscript,
 		},
 	},
-	{
-		Underlined,
-		NewModeSet().
-			With(Underlined).
-			With(DoublyUnderlined),
-		[3]Command{
-			ResetAllUnderlines,
-			SetUnderlined,
-			SetDoublyUnderlined,
-		},
-	},
+}
+
+// Underline modes are synchronized separately because curly, dotted and dashed underlines
+// are set using the same command with different sub-parameters.
+var (
+	styledUnderlineModes = ModeSetWith(CurlyUnderlined, DottedUnderlined, DashedUnderlined)
+	allUnderlineModes    = styledUnderlineModes.With(Underlined).With(DoublyUnderlined)
+)
+
+var underlineSyncCommands = [3]Command{
+	ResetAllUnderlines,
+	SetUnderlined,
+	SetDoublyUnderlined,
+}
+
+var styledUnderlineCommands = []struct {
+	mode    Mode
+	command Command
+}{
+	{CurlyUnderlined, SetCurlyUnderlined},
+	{DottedUnderlined, SetDottedUnderlined},
+	{DashedUnderlined, SetDashedUnderlined},
 }
 
 var modeSyncDualCommandMask = [4][4][3]int{
@@ -412,6 +463,9 @@ var modeNames = map[Mode]string{
 	Overlined:        "Overlined",
 	Superscript:      "Superscript",
 	Subscript:        "Subscript",
+	CurlyUnderlined:  "CurlyUnderlined",
+	DottedUnderlined: "DottedUnderlined",
+	DashedUnderlined: "DashedUnderlined",
 }
 
 var textToMode = map[string]Mode{
@@ -430,4 +484,7 @@ var textToMode = map[string]Mode{
 	"Overlined":        Overlined,
 	"Superscript":      Superscript,
 	"Subscript":        Subscript,
+	"CurlyUnderlined":  CurlyUnderlined,
+	"DottedUnderlined": DottedUnderlined,
+	"DashedUnderlined": DashedUnderlined,
 }
@@ -106,4 +106,19 @@ func TestMode(tt *testing.T) {
 	t.Expect(set.Diff(other).Changed()).ToEqual(sgr.Overlined.ModeSet().With(sgr.Subscript))
 
 	t.Expect(set.Diff(set).ToCommands(nil)).ToEqual(sgr.Sequence(nil))
+
+	t.Run("Underlines", func(t Test) {
+		diff := func(old, new sgr.ModeSet) string {
+			return string(old.Diff(new).ToCommands(nil).Bytes())
+		}
+
+		curly := sgr.CurlyUnderlined.ModeSet()
+		t.Expect(diff(0, curly)).ToEqual("\x1b[4:3m")
+		t.Expect(diff(curly, 0)).ToEqual("\x1b[24m")
+		t.Expect(diff(curly, sgr.DashedUnderlined.ModeSet())).ToEqual("\x1b[4:5m")
+		t.Expect(diff(sgr.Underlined.ModeSet(), sgr.DottedUnderlined.ModeSet())).ToEqual("\x1b[4:4m")
+		t.Expect(diff(curly, sgr.Underlined.ModeSet())).ToEqual("\x1b[4m")
+		t.Expect(diff(curly, sgr.DoublyUnderlined.ModeSet().With(sgr.Bold))).ToEqual("\x1b[1;21m")
+		t.Expect(diff(sgr.ModeSetWith(sgr.Underlined, sgr.DoublyUnderlined), sgr.Underlined.ModeSet())).ToEqual("\x1b[24;4m")
+	})
 }
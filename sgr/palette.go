@@ -0,0 +1,114 @@
+package sgr
+
+// ---
+
+// DefaultPalette is the palette used by default to resolve BasicColor and PaletteColor values to RGBColor values.
+// It has the same values as the default palette of xterm.
+var DefaultPalette = XTermPalette()
+
+// XTermPalette returns a palette with the default xterm colors.
+func XTermPalette() Palette {
+	p := Palette{
+		0x000000, 0xcd0000, 0x00cd00, 0xcdcd00, 0x0000ee, 0xcd00cd, 0x00cdcd, 0xe5e5e5,
+		0x7f7f7f, 0xff0000, 0x00ff00, 0xffff00, 0x5c5cff, 0xff00ff, 0x00ffff, 0xffffff,
+	}
+
+	for i := 0; i != 216; i++ {
+		p[16+i] = RGB(cubeLevels[i/36], cubeLevels[i/6%6], cubeLevels[i%6])
+	}
+
+	for i := 0; i != 24; i++ {
+		level := uint8(8 + 10*i)
+		p[232+i] = RGB(level, level, level)
+	}
+
+	return p
+}
+
+// ---
+
+// Palette is a table of RGB values for all 256 PaletteColor values.
+// First 16 entries define RGB values for BasicColor values.
+type Palette [256]RGBColor
+
+// Resolve returns RGB value of the given color and true,
+// or zero value and false if the color is zero or DefaultColor that cannot be resolved using the palette.
+func (p *Palette) Resolve(color IntoColor) (RGBColor, bool) {
+	return p.resolve(color.Color())
+}
+
+func (p *Palette) resolve(c Color) (RGBColor, bool) {
+	switch c.kind() {
+	case colorKindBasic:
+		return p[c.AsBasicColor()&0xF], true
+	case colorKindPalette:
+		return p[c.AsPaletteColor()], true
+	case colorKindRGB:
+		return c.AsRGBColor(), true
+	default:
+		return 0, false
+	}
+}
+
+// NearestBasicColor returns BasicColor value which RGB value in p is the nearest to the given color.
+func (p *Palette) NearestBasicColor(color RGBColor) BasicColor {
+	best := BasicColor(0)
+	bestDistance := -1
+
+	for i := BasicColor(0); i != 16; i++ {
+		d := colorDistance(p[i], color)
+		if bestDistance < 0 || d < bestDistance {
+			best = i
+			bestDistance = d
+		}
+	}
+
+	return best
+}
+
+// NearestPaletteColor returns PaletteColor value from the standard 6x6x6 color cube or the grayscale ramp
+// which RGB value in p is the nearest to the given color.
+// First 16 colors are never returned because they are usually customized by terminal color schemes.
+func (p *Palette) NearestPaletteColor(color RGBColor) PaletteColor {
+	ci := [3]int{cubeIndex(color.R()), cubeIndex(color.G()), cubeIndex(color.B())}
+	cube := PaletteColor(16 + ci[0]*36 + ci[1]*6 + ci[2])
+
+	avg := (int(color.R()) + int(color.G()) + int(color.B())) / 3
+	gi := (avg - 3) / 10
+	gi = max(0, min(gi, 23))
+	gray := PaletteColor(232 + gi)
+
+	if colorDistance(p[gray], color) < colorDistance(p[cube], color) {
+		return gray
+	}
+
+	return cube
+}
+
+// ---
+
+func cubeIndex(v uint8) int {
+	switch {
+	case v < 48:
+		return 0
+	case v < 115:
+		return 1
+	default:
+		return (int(v) - 35) / 40
+	}
+}
+
+// colorDistance returns weighted squared distance between two colors
+// using a low-cost approximation of human color perception.
+func colorDistance(a, b RGBColor) int {
+	rm := (int(a.R()) + int(b.R())) / 2
+	dr := int(a.R()) - int(b.R())
+	dg := int(a.G()) - int(b.G())
+	db := int(a.B()) - int(b.B())
+
+	return ((512+rm)*dr*dr)>>8 + 4*dg*dg + ((767-rm)*db*db)>>8
+}
+
+// ---
+
+var cubeLevels = [6]uint8{0, 95, 135, 175, 215, 255}
@@ -0,0 +1,40 @@
+package sgr_test
+
+import (
+	"testing"
+
+	. "github.com/pamburus/go-tst/tst"
+
+	"github.com/pamburus/go-ansi-esc/sgr"
+)
+
+func TestPalette(tt *testing.T) {
+	t := New(tt)
+
+	p := sgr.XTermPalette()
+
+	t.Run("Resolve", func(t Test) {
+		t.Expect(p.Resolve(sgr.Red)).ToEqual(sgr.RGB(0xcd, 0, 0), true)
+		t.Expect(p.Resolve(sgr.PaletteColor(16))).ToEqual(sgr.RGB(0, 0, 0), true)
+		t.Expect(p.Resolve(sgr.PaletteColor(196))).ToEqual(sgr.RGB(0xff, 0, 0), true)
+		t.Expect(p.Resolve(sgr.PaletteColor(244))).ToEqual(sgr.RGB(0x80, 0x80, 0x80), true)
+		t.Expect(p.Resolve(sgr.RGB(1, 2, 3))).ToEqual(sgr.RGB(1, 2, 3), true)
+		t.Expect(p.Resolve(sgr.Default)).ToEqual(sgr.RGBColor(0), false)
+		t.Expect(p.Resolve(sgr.Color(0))).ToEqual(sgr.RGBColor(0), false)
+	})
+
+	t.Run("NearestBasicColor", func(t Test) {
+		t.Expect(p.NearestBasicColor(sgr.RGB(250, 10, 10))).ToEqual(sgr.BrightRed)
+		t.Expect(p.NearestBasicColor(sgr.RGB(190, 0, 0))).ToEqual(sgr.Red)
+		t.Expect(p.NearestBasicColor(sgr.RGB(10, 10, 30))).ToEqual(sgr.Black)
+		t.Expect(p.NearestBasicColor(sgr.RGB(250, 250, 250))).ToEqual(sgr.BrightWhite)
+	})
+
+	t.Run("NearestPaletteColor", func(t Test) {
+		t.Expect(p.NearestPaletteColor(sgr.RGB(255, 0, 0))).ToEqual(sgr.PaletteColor(196))
+		t.Expect(p.NearestPaletteColor(sgr.RGB(0, 0, 0))).ToEqual(sgr.PaletteColor(16))
+		t.Expect(p.NearestPaletteColor(sgr.RGB(128, 128, 128))).ToEqual(sgr.PaletteColor(244))
+		t.Expect(p.NearestPaletteColor(sgr.RGB(10, 10, 30))).ToEqual(sgr.PaletteColor(233))
+		t.Expect(p.NearestPaletteColor(sgr.RGB(95, 135, 175))).ToEqual(sgr.PaletteColor(67))
+	})
+}
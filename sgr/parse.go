@@ -0,0 +1,173 @@
+package sgr
+
+import (
+	"bytes"
+	"strconv"
+)
+
+// ---
+
+// IsSequence returns true if data looks like a CSI/SGR sequence starting with "\x1b[" (ESC/CSI) and ending with 'm'
+// with no characters other than decimal digits and parameter separators in between.
+func IsSequence(data []byte) bool {
+	if len(data) < len(seqBegin)+1 || !bytes.HasPrefix(data, []byte(seqBegin)) || data[len(data)-1] != seqEnd {
+		return false
+	}
+
+	for _, c := range data[len(seqBegin) : len(data)-1] {
+		if (c < '0' || c > '9') && c != seqNext && c != seqSub {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ParseSequence parses a binary string containing a single CSI/SGR sequence starting with "\x1b[" (ESC/CSI) and ending with 'm'.
+// Both semicolon and colon separated forms of extended color and underline style parameters are supported.
+// Underline style sub-parameters 0 (none), 1 (single), 2 (double), 3 (curly), 4 (dotted) and 5 (dashed) are kept,
+// and any other underline style is treated as single underline.
+// Commands with unknown codes are preserved as is.
+// In case of invalid parameters ErrInvalidSequence is returned along with all commands that have been parsed successfully.
+func ParseSequence(data []byte) (Sequence, error) {
+	if !IsSequence(data) {
+		return nil, ErrInvalidSequence{string(data)}
+	}
+
+	params := bytes.Split(data[len(seqBegin):len(data)-1], []byte{seqNext})
+	seq := make(Sequence, 0, len(params))
+	var err error
+
+	fail := func() {
+		if err == nil {
+			err = ErrInvalidSequence{string(data)}
+		}
+	}
+
+	for i := 0; i < len(params); i++ {
+		sub := bytes.Split(params[i], []byte{seqSub})
+		code, ok := parseParam(sub[0])
+		if !ok {
+			fail()
+
+			continue
+		}
+
+		switch CommandCode(code) {
+		case CodeSetForegroundColor, CodeSetBackgroundColor, CodeSetUnderlineColor:
+			var args [][]byte
+			if len(sub) > 1 {
+				args = sub[1:]
+			} else {
+				args = params[i+1:]
+			}
+
+			color, n, ok := parseColorArgs(args, len(sub) > 1)
+			if len(sub) == 1 {
+				i += n
+			}
+			if !ok {
+				fail()
+
+				continue
+			}
+
+			switch CommandCode(code) {
+			case CodeSetForegroundColor:
+				seq = append(seq, setForegroundColor(color))
+			case CodeSetBackgroundColor:
+				seq = append(seq, setBackgroundColor(color))
+			default:
+				seq = append(seq, setUnderlineColor(color))
+			}
+		case CodeSetUnderlined:
+			if len(sub) == 1 {
+				seq = append(seq, SetUnderlined)
+
+				break
+			}
+
+			style, ok := parseParam(sub[1])
+			switch {
+			case !ok:
+				fail()
+			case style == 0:
+				seq = append(seq, ResetAllUnderlines)
+			case style == 2:
+				seq = append(seq, SetDoublyUnderlined)
+			case style == 3:
+				seq = append(seq, SetCurlyUnderlined)
+			case style == 4:
+				seq = append(seq, SetDottedUnderlined)
+			case style == 5:
+				seq = append(seq, SetDashedUnderlined)
+			default:
+				seq = append(seq, SetUnderlined)
+			}
+		default:
+			seq = append(seq, commandValid|Command(code))
+		}
+	}
+
+	return seq, err
+}
+
+// ---
+
+func parseParam(param []byte) (uint8, bool) {
+	if len(param) == 0 {
+		return 0, true
+	}
+
+	v, err := strconv.ParseUint(string(param), 10, 8)
+	if err != nil {
+		return 0, false
+	}
+
+	return uint8(v), true
+}
+
+// parseColorArgs parses arguments of an extended color command and returns the color,
+// the number of arguments consumed and true in case of success.
+func parseColorArgs(args [][]byte, colon bool) (Color, int, bool) {
+	if len(args) == 0 {
+		return 0, 0, false
+	}
+
+	kind, ok := parseParam(args[0])
+	if !ok {
+		return 0, 1, false
+	}
+
+	switch kind {
+	case 5:
+		if len(args) < 2 {
+			return 0, len(args), false
+		}
+
+		v, ok := parseParam(args[1])
+
+		return PaletteColor(v).Color(), 2, ok
+	case 2:
+		if len(args) < 4 {
+			return 0, len(args), false
+		}
+
+		if colon && len(args) >= 5 && len(args[1]) == 0 {
+			// Colon separated form with an empty color space identifier.
+			args = args[1:]
+		}
+
+		r, rok := parseParam(args[1])
+		g, gok := parseParam(args[2])
+		b, bok := parseParam(args[3])
+
+		return RGB(r, g, b).Color(), 4, rok && gok && bok
+	default:
+		return 0, 1, false
+	}
+}
+
+// ---
+
+const seqSub = ':'
@@ -0,0 +1,77 @@
+package sgr_test
+
+import (
+	"testing"
+
+	. "github.com/pamburus/go-tst/tst"
+
+	"github.com/pamburus/go-ansi-esc/sgr"
+)
+
+func TestParseSequence(tt *testing.T) {
+	t := New(tt)
+
+	parse := func(input string) sgr.Sequence {
+		seq, err := sgr.ParseSequence([]byte(input))
+		t.Expect(err).ToSucceed()
+
+		return seq
+	}
+
+	t.Run("RoundTrip", func(t Test) {
+		for _, input := range []string{
+			"\x1b[0m",
+			"\x1b[100;36;3m",
+			"\x1b[49;38;5;12;3m",
+			"\x1b[48;5;12;38;2;128;140;192;1m",
+			"\x1b[58;5;2;4;21;24m",
+			"\x1b[10;11m",
+		} {
+			t.Expect(string(parse(input).Bytes())).ToEqual(input)
+		}
+	})
+
+	t.Run("Empty", func(t Test) {
+		t.Expect(parse("\x1b[m")).ToEqual(sgr.Sequence{sgr.ResetAll})
+		t.Expect(parse("\x1b[;1m")).ToEqual(sgr.Sequence{sgr.ResetAll, sgr.SetBold})
+	})
+
+	t.Run("Colon", func(t Test) {
+		t.Expect(parse("\x1b[38:2::1:2:3;48:2:4:5:6;58:5:7m")).ToEqual(sgr.Sequence{
+			sgr.SetForegroundColor(sgr.RGB(1, 2, 3)),
+			sgr.SetBackgroundColor(sgr.RGB(4, 5, 6)),
+			sgr.SetUnderlineColor(sgr.PaletteColor(7)),
+		})
+		t.Expect(parse("\x1b[4:0;4:1;4:2;4:3;4:4;4:5;4:6m")).ToEqual(sgr.Sequence{
+			sgr.ResetAllUnderlines,
+			sgr.SetUnderlined,
+			sgr.SetDoublyUnderlined,
+			sgr.SetCurlyUnderlined,
+			sgr.SetDottedUnderlined,
+			sgr.SetDashedUnderlined,
+			sgr.SetUnderlined,
+		})
+	})
+
+	t.Run("Invalid", func(t Test) {
+		t.Expect(sgr.ParseSequence([]byte("\x1b[2K"))).ToFailWith(sgr.ErrInvalidSequence{})
+		t.Expect(sgr.ParseSequence([]byte("\x1b[1"))).ToFailWith(sgr.ErrInvalidSequence{})
+		t.Expect(sgr.ParseSequence([]byte("\x1b[?1m"))).ToFailWith(sgr.ErrInvalidSequence{})
+		t.Expect(sgr.ParseSequence([]byte("\x1b[1;4:x;3m"))).ToFailWith(sgr.ErrInvalidSequence{})
+
+		for _, input := range []string{
+			"\x1b[1;256;3m",
+			"\x1b[1;38;5;256;3m",
+			"\x1b[1;38;7;3m",
+			"\x1b[1;38:2:1:2:256;3m",
+			"\x1b[1;4:256;3m",
+			"\x1b[1;38:5;3m",
+			"\x1b[1;38;5m",
+			"\x1b[1;38;2;1;2m",
+		} {
+			seq, err := sgr.ParseSequence([]byte(input))
+			t.Expect(err).To(MatchError(sgr.ErrInvalidSequence{Value: input}))
+			t.Expect(seq[0]).ToEqual(sgr.SetBold)
+		}
+	})
+}
@@ -0,0 +1,148 @@
+package sgr
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ---
+
+// Complete set of valid ColorProfile values.
+const (
+	// ColorProfileTrueColor allows all colors including RGBColor values.
+	ColorProfileTrueColor ColorProfile = iota
+	// ColorProfile256 allows BasicColor and PaletteColor values, RGBColor values are converted to the nearest PaletteColor.
+	ColorProfile256
+	// ColorProfile16 allows only BasicColor values, other colors are converted to the nearest BasicColor.
+	// Underline color is not supported by such terminals and is always left default.
+	ColorProfile16
+	// ColorProfileNone does not allow any colors or modes, no SGR sequences are emitted at all.
+	ColorProfileNone
+)
+
+// ColorProfile defines a set of colors and modes supported by a terminal or any other target of styled output.
+type ColorProfile uint8
+
+// Convert converts the given color to the nearest color supported by p using DefaultPalette.
+// Zero color and DefaultColor are returned as is.
+// For ColorProfileNone all colors are converted to DefaultColor.
+func (p ColorProfile) Convert(color IntoColor) Color {
+	return p.convert(color.Color())
+}
+
+// ConvertStyle converts colors of the given style to the nearest colors supported by p the same way as Writer does.
+// Underline color is reset to zero for ColorProfile16 because such terminals do not support it.
+// For ColorProfileNone an empty style is returned.
+func (p ColorProfile) ConvertStyle(style Style) Style {
+	if p == ColorProfileNone {
+		return Style{}
+	}
+
+	style.Background = p.convert(style.Background)
+	style.Foreground = p.convert(style.Foreground)
+	if p == ColorProfile16 {
+		style.UnderlineColor = 0
+	} else {
+		style.UnderlineColor = p.convert(style.UnderlineColor)
+	}
+
+	return style
+}
+
+func (p ColorProfile) convert(c Color) Color {
+	switch c.kind() {
+	case colorKindBasic, colorKindPalette, colorKindRGB:
+	default:
+		return c
+	}
+
+	switch p {
+	case ColorProfile256:
+		if c.IsRGBColor() {
+			return DefaultPalette.NearestPaletteColor(c.AsRGBColor()).Color()
+		}
+	case ColorProfile16:
+		switch {
+		case c.IsPaletteColor() && c.AsPaletteColor() < 16:
+			return BasicColor(c.AsPaletteColor()).Color()
+		case !c.IsBasicColor():
+			rgb, _ := DefaultPalette.resolve(c)
+
+			return DefaultPalette.NearestBasicColor(rgb).Color()
+		}
+	case ColorProfileNone:
+		return Default.Color()
+	}
+
+	return c
+}
+
+// String returns textual description of p that can be used for debugging or logging purposes.
+func (p ColorProfile) String() string {
+	if name, ok := colorProfileNames[p]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("<!0x%02x>", uint8(p))
+}
+
+// Validate check that p has a valid value.
+func (p ColorProfile) Validate() error {
+	if _, ok := colorProfileNames[p]; !ok {
+		return ErrInvalidColorProfileValue{p}
+	}
+
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler interface
+// that allows ColorProfile to be used in any compatible marshaler like JSON, YAML, etc.
+func (p ColorProfile) MarshalText() ([]byte, error) {
+	err := p.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(p.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler interface
+// that allows ColorProfile to be used in any compatible unmarshaler like JSON, YAML, etc.
+func (p *ColorProfile) UnmarshalText(data []byte) error {
+	text := strings.TrimSpace(string(data))
+
+	for value, name := range colorProfileNames {
+		if strings.EqualFold(text, name) {
+			*p = value
+
+			return nil
+		}
+	}
+
+	return ErrInvalidColorProfileText{text}
+}
+
+func (p ColorProfile) convertState(st state) state {
+	if p == ColorProfileNone {
+		return defaultState
+	}
+
+	st.bgc = p.convert(st.bgc)
+	st.fgc = p.convert(st.fgc)
+	if p == ColorProfile16 {
+		st.ulc = Default.Color()
+	} else {
+		st.ulc = p.convert(st.ulc)
+	}
+
+	return st
+}
+
+// ---
+
+var colorProfileNames = map[ColorProfile]string{
+	ColorProfileTrueColor: "truecolor",
+	ColorProfile256:       "256",
+	ColorProfile16:        "16",
+	ColorProfileNone:      "none",
+}
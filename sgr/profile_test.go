@@ -0,0 +1,89 @@
+package sgr_test
+
+import (
+	"testing"
+
+	. "github.com/pamburus/go-tst/tst"
+
+	"github.com/pamburus/go-ansi-esc/sgr"
+)
+
+func TestColorProfile(tt *testing.T) {
+	t := New(tt)
+
+	t.Run("Convert", func(t Test) {
+		t.Run("TrueColor", func(t Test) {
+			p := sgr.ColorProfileTrueColor
+			t.Expect(p.Convert(sgr.RGB(1, 2, 3))).ToEqual(sgr.RGB(1, 2, 3).Color())
+			t.Expect(p.Convert(sgr.PaletteColor(100))).ToEqual(sgr.PaletteColor(100).Color())
+		})
+
+		t.Run("256", func(t Test) {
+			p := sgr.ColorProfile256
+			t.Expect(p.Convert(sgr.RGB(255, 0, 0))).ToEqual(sgr.PaletteColor(196).Color())
+			t.Expect(p.Convert(sgr.PaletteColor(100))).ToEqual(sgr.PaletteColor(100).Color())
+			t.Expect(p.Convert(sgr.Cyan)).ToEqual(sgr.Cyan.Color())
+			t.Expect(p.Convert(sgr.Default)).ToEqual(sgr.Default.Color())
+		})
+
+		t.Run("16", func(t Test) {
+			p := sgr.ColorProfile16
+			t.Expect(p.Convert(sgr.RGB(255, 0, 0))).ToEqual(sgr.BrightRed.Color())
+			t.Expect(p.Convert(sgr.PaletteColor(4))).ToEqual(sgr.Blue.Color())
+			t.Expect(p.Convert(sgr.PaletteColor(232))).ToEqual(sgr.Black.Color())
+			t.Expect(p.Convert(sgr.Cyan)).ToEqual(sgr.Cyan.Color())
+			t.Expect(p.Convert(sgr.Color(0))).ToEqual(sgr.Color(0))
+		})
+
+		t.Run("None", func(t Test) {
+			p := sgr.ColorProfileNone
+			t.Expect(p.Convert(sgr.RGB(255, 0, 0))).ToEqual(sgr.Default.Color())
+			t.Expect(p.Convert(sgr.Cyan)).ToEqual(sgr.Default.Color())
+		})
+	})
+
+	t.Run("ConvertStyle", func(t Test) {
+		style := sgr.Style{
+			Background:     sgr.RGB(255, 0, 0).Color(),
+			Foreground:     sgr.PaletteColor(4).Color(),
+			UnderlineColor: sgr.RGB(255, 0, 0).Color(),
+			Modes:          sgr.Bold.ModeSet(),
+		}
+
+		t.Expect(sgr.ColorProfileTrueColor.ConvertStyle(style)).ToEqual(style)
+		t.Expect(sgr.ColorProfile256.ConvertStyle(style)).ToEqual(sgr.Style{
+			Background:     sgr.PaletteColor(196).Color(),
+			Foreground:     sgr.PaletteColor(4).Color(),
+			UnderlineColor: sgr.PaletteColor(196).Color(),
+			Modes:          sgr.Bold.ModeSet(),
+		})
+		t.Expect(sgr.ColorProfile16.ConvertStyle(style)).ToEqual(sgr.Style{
+			Background: sgr.BrightRed.Color(),
+			Foreground: sgr.Blue.Color(),
+			Modes:      sgr.Bold.ModeSet(),
+		})
+		t.Expect(sgr.ColorProfileNone.ConvertStyle(style)).ToEqual(sgr.Style{})
+	})
+
+	t.Run("String", func(t Test) {
+		t.Expect(sgr.ColorProfileTrueColor.String()).ToEqual("truecolor")
+		t.Expect(sgr.ColorProfile256.String()).ToEqual("256")
+		t.Expect(sgr.ColorProfile16.String()).ToEqual("16")
+		t.Expect(sgr.ColorProfileNone.String()).ToEqual("none")
+		t.Expect(sgr.ColorProfile(10).String()).ToEqual("<!0x0a>")
+	})
+
+	t.Run("MarshalText", func(t Test) {
+		t.Expect(sgr.ColorProfile256.MarshalText()).ToSucceed().AndResult().ToEqual([]byte("256"))
+		t.Expect(sgr.ColorProfile(10).MarshalText()).ToFailWith(sgr.ErrInvalidColorProfileValue{Value: 10})
+	})
+
+	t.Run("UnmarshalText", func(t Test) {
+		var p sgr.ColorProfile
+		t.Expect(p.UnmarshalText([]byte(" TrueColor "))).ToSucceed()
+		t.Expect(p).ToEqual(sgr.ColorProfileTrueColor)
+		t.Expect(p.UnmarshalText([]byte("16"))).ToSucceed()
+		t.Expect(p).ToEqual(sgr.ColorProfile16)
+		t.Expect(p.UnmarshalText([]byte("8"))).ToFailWith(sgr.ErrInvalidColorProfileText{Value: "8"})
+	})
+}
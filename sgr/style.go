@@ -0,0 +1,221 @@
+package sgr
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/veggiemonk/strcase"
+)
+
+// ---
+
+// Style is a combination of colors and modes that can be applied at once.
+// Zero colors are not applied and leave the corresponding current color unchanged.
+// Modes are added to the current modes.
+type Style struct {
+	Background     Color
+	Foreground     Color
+	UnderlineColor Color
+	Modes          ModeSet
+}
+
+// IsZero returns true if s does not change anything being applied.
+func (s Style) IsZero() bool {
+	return s == Style{}
+}
+
+// WithOther returns a new Style that is s with other applied on top of it.
+// Non-zero colors of other override colors of s and modes of other are added to modes of s.
+func (s Style) WithOther(other Style) Style {
+	if !other.Background.IsZero() {
+		s.Background = other.Background
+	}
+	if !other.Foreground.IsZero() {
+		s.Foreground = other.Foreground
+	}
+	if !other.UnderlineColor.IsZero() {
+		s.UnderlineColor = other.UnderlineColor
+	}
+	s.Modes |= other.Modes
+
+	return s
+}
+
+func (s Style) apply(st state) state {
+	if !s.Background.IsZero() {
+		st.bgc = s.Background
+	}
+	if !s.Foreground.IsZero() {
+		st.fgc = s.Foreground
+	}
+	if !s.UnderlineColor.IsZero() {
+		st.ulc = s.UnderlineColor
+	}
+	st.modes |= s.Modes
+
+	return st
+}
+
+// normalized returns a copy of s with all DefaultColor values replaced with zero colors.
+func (s Style) normalized() Style {
+	if s.Background.IsDefaultColor() {
+		s.Background = 0
+	}
+	if s.Foreground.IsDefaultColor() {
+		s.Foreground = 0
+	}
+	if s.UnderlineColor.IsDefaultColor() {
+		s.UnderlineColor = 0
+	}
+
+	return s
+}
+
+// String returns textual representation of s in the same form as accepted by ParseStyle.
+func (s Style) String() string {
+	var parts []string
+
+	for _, mode := range s.Modes.ModeList() {
+		parts = append(parts, styleWord(mode.String()))
+	}
+	if !s.Foreground.IsZero() {
+		parts = append(parts, styleColorWord(s.Foreground))
+	}
+	if !s.Background.IsZero() {
+		parts = append(parts, styleWordOn, styleColorWord(s.Background))
+	}
+	if !s.UnderlineColor.IsZero() {
+		parts = append(parts, styleWordUnderlinePrefix+styleColorWord(s.UnderlineColor))
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// MarshalText implements encoding.TextMarshaler interface
+// that allows Style to be used in any compatible marshaler like JSON, YAML, etc.
+func (s Style) MarshalText() ([]byte, error) {
+	for _, c := range []Color{s.Background, s.Foreground, s.UnderlineColor} {
+		err := c.Validate()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler interface
+// that allows Style to be used in any compatible unmarshaler like JSON, YAML, etc.
+func (s *Style) UnmarshalText(data []byte) error {
+	style, err := ParseStyle(string(data))
+	if err != nil {
+		return err
+	}
+
+	*s = style
+
+	return nil
+}
+
+// ---
+
+// ParseStyle parses textual style representation like "bold underlined bright-red on blue".
+//
+// The text consists of words separated by spaces or commas, case is ignored:
+//   - any Mode name like "bold", "crossed-out" or "CrossedOut", or one of short aliases "b", "i", "u", "s";
+//   - any Color text form like "red", "bright-red", "bright red", "#0f" or "#ff8000" sets the foreground color;
+//   - "on" followed by a color sets the background color;
+//   - "fg:", "bg:" or "ul:" prefix followed by a color sets the foreground, background or underline color.
+func ParseStyle(text string) (Style, error) {
+	var style Style
+
+	words := strings.FieldsFunc(text, func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	})
+
+	color := func(i *int) (Color, bool) {
+		word := words[*i]
+		if strings.EqualFold(word, textBright) && *i+1 < len(words) {
+			*i++
+			word += "-" + words[*i]
+		}
+
+		var c Color
+		if c.UnmarshalText([]byte(word)) != nil || c.IsZero() {
+			return 0, false
+		}
+
+		return c, true
+	}
+
+	for i := 0; i < len(words); i++ {
+		word := words[i]
+		lower := strings.ToLower(word)
+		ok := true
+
+		switch {
+		case lower == styleWordOn && i+1 < len(words):
+			i++
+			style.Background, ok = color(&i)
+		case strings.HasPrefix(lower, styleWordForegroundPrefix):
+			ok = style.Foreground.UnmarshalText([]byte(word[len(styleWordForegroundPrefix):])) == nil
+		case strings.HasPrefix(lower, styleWordBackgroundPrefix):
+			ok = style.Background.UnmarshalText([]byte(word[len(styleWordBackgroundPrefix):])) == nil
+		case strings.HasPrefix(lower, styleWordUnderlinePrefix):
+			ok = style.UnderlineColor.UnmarshalText([]byte(word[len(styleWordUnderlinePrefix):])) == nil
+		default:
+			if mode, found := styleModeAliases[lower]; found {
+				style.Modes = style.Modes.With(mode)
+
+				break
+			}
+
+			var mode Mode
+			if mode.unmarshalText(word) == nil || mode.unmarshalText(lower) == nil {
+				style.Modes = style.Modes.With(mode)
+
+				break
+			}
+
+			var c Color
+			c, ok = color(&i)
+			if ok {
+				style.Foreground = c
+			}
+		}
+
+		if !ok {
+			return Style{}, ErrInvalidStyleText{text, ErrInvalidColorText{word, nil}}
+		}
+	}
+
+	return style, nil
+}
+
+// ---
+
+func styleWord(name string) string {
+	return strcase.Kebab(name)
+}
+
+func styleColorWord(c Color) string {
+	if c.IsBasicColor() || c.IsDefaultColor() {
+		return styleWord(c.String())
+	}
+
+	return c.String()
+}
+
+const (
+	styleWordOn               = "on"
+	styleWordForegroundPrefix = "fg:"
+	styleWordBackgroundPrefix = "bg:"
+	styleWordUnderlinePrefix  = "ul:"
+)
+
+var styleModeAliases = map[string]Mode{
+	"b": Bold,
+	"i": Italic,
+	"u": Underlined,
+	"s": CrossedOut,
+}
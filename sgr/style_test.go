@@ -0,0 +1,75 @@
+package sgr_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/pamburus/go-tst/tst"
+
+	"github.com/pamburus/go-ansi-esc/sgr"
+)
+
+func TestStyle(tt *testing.T) {
+	t := New(tt)
+
+	red := sgr.Style{Foreground: sgr.Red.Color()}
+	blue := sgr.Style{Background: sgr.Blue.Color(), Modes: sgr.Bold.ModeSet()}
+
+	t.Run("WithOther", func(t Test) {
+		t.Expect(red.WithOther(blue)).ToEqual(sgr.Style{
+			Foreground: sgr.Red.Color(),
+			Background: sgr.Blue.Color(),
+			Modes:      sgr.Bold.ModeSet(),
+		})
+		t.Expect(blue.WithOther(sgr.Style{Background: sgr.Default.Color()}).Background).ToEqual(sgr.Default.Color())
+		t.Expect(red.WithOther(sgr.Style{})).ToEqual(red)
+	})
+
+	t.Run("Parse", func(t Test) {
+		t.Expect(sgr.ParseStyle("bold underlined bright red on blue")).ToSucceed().AndResult().ToEqual(sgr.Style{
+			Foreground: sgr.BrightRed.Color(),
+			Background: sgr.Blue.Color(),
+			Modes:      sgr.ModeSetWith(sgr.Bold, sgr.Underlined),
+		})
+		t.Expect(sgr.ParseStyle("B, Crossed-Out,fg:#ff8000 bg:#0f ul:default")).ToSucceed().AndResult().ToEqual(sgr.Style{
+			Foreground:     sgr.RGB(0xff, 0x80, 0x00).Color(),
+			Background:     sgr.PaletteColor(15).Color(),
+			UnderlineColor: sgr.Default.Color(),
+			Modes:          sgr.ModeSetWith(sgr.Bold, sgr.CrossedOut),
+		})
+		t.Expect(sgr.ParseStyle("  ")).ToSucceed().AndResult().ToEqual(sgr.Style{})
+		t.Expect(sgr.ParseStyle("u BrightGreen DoublyUnderlined")).ToSucceed().AndResult().ToEqual(sgr.Style{
+			Foreground: sgr.BrightGreen.Color(),
+			Modes:      sgr.ModeSetWith(sgr.Underlined, sgr.DoublyUnderlined),
+		})
+
+		for _, text := range []string{"boldly", "red on", "on purple", "fg:#12345", "bright"} {
+			t.Run(text, func(t Test) {
+				t.Expect(sgr.ParseStyle(text)).ToFailWith(sgr.ErrInvalidStyleText{Value: text})
+			})
+		}
+	})
+
+	t.Run("String", func(t Test) {
+		style := sgr.Style{
+			Foreground:     sgr.BrightRed.Color(),
+			Background:     sgr.PaletteColor(42).Color(),
+			UnderlineColor: sgr.RGB(1, 2, 3).Color(),
+			Modes:          sgr.ModeSetWith(sgr.Bold, sgr.CrossedOut),
+		}
+		t.Expect(style.String()).ToEqual("bold crossed-out bright-red on #2a ul:#010203")
+		t.Expect(sgr.ParseStyle(style.String())).ToSucceed().AndResult().ToEqual(style)
+		t.Expect(sgr.Style{}.String()).ToEqual("")
+	})
+
+	t.Run("JSON", func(t Test) {
+		data := t.Expect(json.Marshal(map[string]sgr.Style{"error": red.WithOther(blue)})).ToSucceed().AndResult()
+		data.ToEqual([]byte(`{"error":"bold red on blue"}`))
+
+		var styles map[string]sgr.Style
+		t.Expect(json.Unmarshal([]byte(`{"error":"bold red on blue"}`), &styles)).ToSucceed()
+		t.Expect(styles["error"]).ToEqual(red.WithOther(blue))
+		t.Expect(json.Unmarshal([]byte(`{"error":"nope"}`), &styles)).ToFailWith(sgr.ErrInvalidStyleText{})
+		t.Expect(json.Marshal(sgr.Style{Foreground: sgr.Color(0x7f000000)})).ToFailWith(sgr.ErrInvalidColorValue{})
+	})
+}
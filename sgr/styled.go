@@ -0,0 +1,123 @@
+package sgr
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+)
+
+// ---
+
+// Styled returns a value that being formatted using fmt package functions or Writer's Printf, Print and Println methods
+// renders the given value with the style applied only to it.
+// Width is applied to the formatted value using its display width, and padding is not styled,
+// except for zero padding requested with '0' flag that is a part of the styled value.
+//
+// The %T verb is handled by fmt package itself without calling Format, so it cannot be forwarded to the value
+// and reports the type of the wrapper instead, which is an unexported type when formatted using Writer's Printf.
+// Use %T with the value itself to get its type.
+func Styled(value any, style Style) StyledValue {
+	return StyledValue{value, style}
+}
+
+// StyledValue is a value paired with a style that should be applied to it when it is formatted.
+type StyledValue struct {
+	Value any
+	Style Style
+}
+
+// Format implements fmt.Formatter interface.
+//
+// When StyledValue is formatted outside Writer, the style is applied relative to terminal defaults
+// and the formatted value is followed by a sequence that resets all changed attributes.
+func (v StyledValue) Format(f fmt.State, verb rune) {
+	text := v.formatValue(f, verb)
+
+	v.format(f, text, func() {
+		to := v.Style.apply(defaultState)
+		buf := OptimizeIncremental.transition(nil, defaultState, to).Render(nil)
+		buf = append(buf, text...)
+		buf = OptimizeIncremental.transition(nil, to, defaultState).Render(buf)
+		_, _ = f.Write(buf)
+	})
+}
+
+func (v StyledValue) format(f fmt.State, text []byte, content func()) {
+	width, ok := f.Width()
+	if !ok {
+		content()
+
+		return
+	}
+
+	padding := width - Width(text)
+	if !f.Flag('-') {
+		writePadding(f, padding)
+	}
+	content()
+	if f.Flag('-') {
+		writePadding(f, padding)
+	}
+}
+
+func (v StyledValue) formatValue(f fmt.State, verb rune) []byte {
+	spec := make([]byte, 0, 16)
+	spec = append(spec, '%')
+	for _, flag := range []byte("+# 0") {
+		if f.Flag(int(flag)) {
+			spec = append(spec, flag)
+		}
+	}
+	if f.Flag('0') && !f.Flag('-') {
+		// Zero padding is a part of the value, like in "-0042", so the width is applied by fmt itself.
+		if width, ok := f.Width(); ok {
+			spec = strconv.AppendInt(spec, int64(width), 10)
+		}
+	}
+	if precision, ok := f.Precision(); ok {
+		spec = append(spec, '.')
+		spec = strconv.AppendInt(spec, int64(precision), 10)
+	}
+	spec = append(spec, string(verb)...)
+
+	return fmt.Appendf(nil, string(spec), v.Value)
+}
+
+// ---
+
+// boundStyledValue is a StyledValue that collects its styled segments into w when formatted by Writer's Printf.
+// See Styled for the limitation regarding the %T verb.
+type boundStyledValue struct {
+	StyledValue
+	w *Writer
+}
+
+func (v boundStyledValue) Format(f fmt.State, verb rune) {
+	text := v.formatValue(f, verb)
+
+	v.format(f, text, func() {
+		v.w.styled = append(v.w.styled, styledSegment{v.Style, text})
+		_, _ = f.Write([]byte(styledMarker))
+	})
+}
+
+// ---
+
+type styledSegment struct {
+	style Style
+	text  []byte
+}
+
+// ---
+
+func writePadding(f fmt.State, n int) {
+	if n > 0 {
+		_, _ = f.Write(bytes.Repeat([]byte{' '}, n))
+	}
+}
+
+// ---
+
+// styledMarker is an APC sequence that marks the place of a styled segment in a formatted text.
+// It is used only internally and never reaches the target writer.
+const styledMarker = "\x1b_go-ansi-esc/sgr.Styled\x1b\\"
@@ -0,0 +1,88 @@
+package sgr_test
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	. "github.com/pamburus/go-tst/tst"
+
+	"github.com/pamburus/go-ansi-esc/sgr"
+)
+
+func TestStyled(tt *testing.T) {
+	t := New(tt)
+
+	keyStyle := sgr.Style{
+		Foreground: sgr.BrightGreen.Color(),
+		Modes:      sgr.Underlined.ModeSet(),
+	}
+
+	t.Run("Standalone", func(t Test) {
+		t.Expect(
+			fmt.Sprintf("%v=%v", sgr.Styled("key", keyStyle), 42),
+		).ToEqual(
+			"\x1b[92;4mkey\x1b[0m=42",
+		)
+	})
+
+	t.Run("Width", func(t Test) {
+		t.Expect(
+			fmt.Sprintf("[%6v][%-6v][%.2s]", sgr.Styled("kéy", keyStyle), sgr.Styled(1, sgr.Style{}), sgr.Styled("abc", sgr.Style{})),
+		).ToEqual(
+			"[   \x1b[92;4mkéy\x1b[0m][1     ][ab]",
+		)
+	})
+
+	t.Run("ZeroPadding", func(t Test) {
+		t.Expect(
+			fmt.Sprintf("[%05v][%+06.1f][%-05v]", sgr.Styled(42, keyStyle), sgr.Styled(-1.25, sgr.Style{}), sgr.Styled(7, sgr.Style{})),
+		).ToEqual(
+			"[\x1b[92;4m00042\x1b[0m][-001.2][7    ]",
+		)
+	})
+
+	t.Run("Writer", func(t Test) {
+		t.Run("Printf", func(t Test) {
+			buf := bytes.NewBuffer(nil)
+			w := sgr.NewWriter(buf)
+			w.PushBackgroundColor(sgr.Blue)
+			t.Expect(w.Printf("%v: %-4v|", sgr.Styled("key", keyStyle), sgr.Styled("v", sgr.Style{Foreground: sgr.Red.Color()}))).
+				ToSucceed().AndResult().ToEqual(10)
+			w.PopBackgroundColor()
+			t.Expect(w.Flush()).ToSucceed()
+			t.Expect(buf.String()).ToEqual("\x1b[44;92;4mkey\x1b[39;24m: \x1b[31mv\x1b[39m   |\x1b[0m")
+		})
+
+		t.Run("Print", func(t Test) {
+			buf := bytes.NewBuffer(nil)
+			w := sgr.NewWriter(buf)
+			t.Expect(w.Print("a", sgr.Styled("b", keyStyle), 1)).ToSucceed().AndResult().ToEqual(4)
+			t.Expect(w.Flush()).ToSucceed()
+			t.Expect(buf.String()).ToEqual("a\x1b[92;4mb\x1b[0m 1")
+		})
+
+		t.Run("Println", func(t Test) {
+			buf := bytes.NewBuffer(nil)
+			w := sgr.NewWriter(buf)
+			t.Expect(w.Println(sgr.Styled("a", keyStyle), "b")).ToSucceed().AndResult().ToEqual(4)
+			t.Expect(buf.String()).ToEqual("\x1b[92;4ma\x1b[0m b\n")
+		})
+
+		t.Run("WriteString", func(t Test) {
+			buf := bytes.NewBuffer(nil)
+			w := sgr.NewWriter(buf)
+			w.SetStyle(keyStyle)
+			t.Expect(w.WriteString("abc")).ToSucceed().AndResult().ToEqual(3)
+			t.Expect(buf.String()).ToEqual("\x1b[92;4mabc")
+		})
+
+		t.Run("Error", func(t Test) {
+			w := sgr.NewWriter(failingWriter{})
+			t.Expect(w.Printf("%v", sgr.Styled("a", keyStyle))).ToFailWith(errFailingWriterError)
+			t.Expect(w.Printf("a%v", sgr.Styled("b", keyStyle))).ToFailWith(errFailingWriterError)
+			w.SetStyle(keyStyle)
+			t.Expect(w.WriteString("a")).ToFailWith(errFailingWriterError)
+		})
+	})
+}
@@ -0,0 +1,266 @@
+package sgr
+
+import (
+	"bytes"
+	"strings"
+)
+
+// ---
+
+// NewStyledText constructs a new StyledText containing the given text having the given style.
+func NewStyledText(text string, style Style) StyledText {
+	return StyledText{}.Append(text, style)
+}
+
+// ParseStyledText constructs a new StyledText from data containing CSI/SGR sequences.
+// All SGR sequences are interpreted relative to terminal defaults, all other escape sequences are dropped.
+func ParseStyledText(data []byte) StyledText {
+	var interp Interpreter
+	var b styledTextBuilder
+
+	for len(data) != 0 {
+		n, token, _ := ScanTokens(data, true)
+		data = data[n:]
+
+		if IsEscape(token) {
+			if IsSequence(token) {
+				seq, _ := ParseSequence(token)
+				interp.Apply(seq)
+			}
+
+			continue
+		}
+
+		b.append(string(token), interp.Style())
+	}
+
+	return b.result()
+}
+
+// ---
+
+// StyledText is an immutable plain text with a list of style runs covering it.
+//
+// Styles of the runs have the same meaning as when they are applied using Writer.SetStyle:
+// zero colors mean that the colors are inherited from the context where the text is written,
+// which is terminal defaults in case the text is rendered standalone.
+// DefaultColor values are normalized to zero colors.
+// All offsets are byte offsets in the plain text.
+type StyledText struct {
+	text string
+	runs []styledRun
+}
+
+// StyledRun is a range of StyledText having the same style.
+type StyledRun struct {
+	Start int
+	End   int
+	Style Style
+}
+
+// Len returns the length of the plain text in bytes.
+func (t StyledText) Len() int {
+	return len(t.text)
+}
+
+// IsEmpty returns true if t has no text.
+func (t StyledText) IsEmpty() bool {
+	return len(t.text) == 0
+}
+
+// Plain returns the plain text without styles.
+func (t StyledText) Plain() string {
+	return t.text
+}
+
+// Runs returns the list of style runs covering the whole text.
+// Adjacent runs always have different styles.
+func (t StyledText) Runs() []StyledRun {
+	result := make([]StyledRun, len(t.runs))
+	start := 0
+	for i, run := range t.runs {
+		result[i] = StyledRun{start, run.end, run.style}
+		start = run.end
+	}
+
+	return result
+}
+
+// StyleAt returns the style of the byte at the given offset.
+func (t StyledText) StyleAt(offset int) Style {
+	for _, run := range t.runs {
+		if offset < run.end {
+			return run.style
+		}
+	}
+
+	return Style{}
+}
+
+// Append returns a new StyledText with the given text having the given style appended to t.
+func (t StyledText) Append(text string, style Style) StyledText {
+	var b styledTextBuilder
+	b.appendText(t)
+	b.append(text, style)
+
+	return b.result()
+}
+
+// Concat returns a new StyledText that is concatenation of t and all others.
+func (t StyledText) Concat(others ...StyledText) StyledText {
+	var b styledTextBuilder
+	b.appendText(t)
+	for _, other := range others {
+		b.appendText(other)
+	}
+
+	return b.result()
+}
+
+// Slice returns a new StyledText containing the part of t between start and end offsets.
+// It panics if the offsets are out of range like slicing a string does.
+func (t StyledText) Slice(start, end int) StyledText {
+	_ = t.text[start:end]
+
+	var b styledTextBuilder
+	b.appendSlice(t, start, end)
+
+	return b.result()
+}
+
+// Insert returns a new StyledText with other inserted into t at the given offset.
+// Styles of other are applied on top of the style t has at the insertion point,
+// so that zero colors of other inherit the surrounding colors.
+// It panics if the offset is out of range.
+func (t StyledText) Insert(offset int, other StyledText) StyledText {
+	_ = t.text[offset:]
+
+	base := t.StyleAt(max(offset-1, 0))
+
+	var b styledTextBuilder
+	b.appendSlice(t, 0, offset)
+	b.appendTextOver(other, base)
+	b.appendSlice(t, offset, len(t.text))
+
+	return b.result()
+}
+
+// ReplaceAll returns a new StyledText with all non-overlapping occurrences of old in the plain text replaced by replacement.
+// Styles of replacement are applied on top of the style the replaced text had at the beginning of each occurrence,
+// so that replacing a word with the same word having a different foreground color highlights it.
+// If old is empty, t is returned as is.
+func (t StyledText) ReplaceAll(old string, replacement StyledText) StyledText {
+	if old == "" || !strings.Contains(t.text, old) {
+		return t
+	}
+
+	var b styledTextBuilder
+	start := 0
+	for {
+		i := strings.Index(t.text[start:], old)
+		if i < 0 {
+			break
+		}
+		i += start
+
+		b.appendSlice(t, start, i)
+		b.appendTextOver(replacement, t.StyleAt(i))
+		start = i + len(old)
+	}
+	b.appendSlice(t, start, len(t.text))
+
+	return b.result()
+}
+
+// WithBaseStyle returns a new StyledText with styles of all runs applied on top of the given base style.
+func (t StyledText) WithBaseStyle(base Style) StyledText {
+	var b styledTextBuilder
+	b.appendTextOver(t, base)
+
+	return b.result()
+}
+
+// Render renders t with the minimal needed CSI/SGR sequences relative to terminal defaults and appends the result to buf.
+// If the text ends with a non-default style, a sequence resetting it is appended at the end.
+func (t StyledText) Render(buf []byte) []byte {
+	seq := make(Sequence, 0, 8)
+	prev := defaultState
+	start := 0
+
+	for _, run := range t.runs {
+		st := run.style.apply(defaultState)
+		buf = OptimizeIncremental.transition(seq[:0], prev, st).Render(buf)
+		buf = append(buf, t.text[start:run.end]...)
+		prev = st
+		start = run.end
+	}
+
+	return OptimizeIncremental.transition(seq[:0], prev, defaultState).Render(buf)
+}
+
+// Bytes returns the same result as Render collected into a new byte slice.
+func (t StyledText) Bytes() []byte {
+	return t.Render(make([]byte, 0, len(t.text)+len(t.runs)*8))
+}
+
+// String returns the same result as Render as a string.
+func (t StyledText) String() string {
+	return string(t.Bytes())
+}
+
+// ---
+
+type styledRun struct {
+	end   int
+	style Style
+}
+
+// ---
+
+type styledTextBuilder struct {
+	text bytes.Buffer
+	runs []styledRun
+}
+
+func (b *styledTextBuilder) append(text string, style Style) {
+	if text == "" {
+		return
+	}
+
+	b.text.WriteString(text)
+	style = style.normalized()
+
+	if n := len(b.runs); n != 0 && b.runs[n-1].style == style {
+		b.runs[n-1].end = b.text.Len()
+
+		return
+	}
+
+	b.runs = append(b.runs, styledRun{b.text.Len(), style})
+}
+
+func (b *styledTextBuilder) appendText(t StyledText) {
+	b.appendSlice(t, 0, len(t.text))
+}
+
+func (b *styledTextBuilder) appendTextOver(t StyledText, base Style) {
+	start := 0
+	for _, run := range t.runs {
+		b.append(t.text[start:run.end], base.WithOther(run.style))
+		start = run.end
+	}
+}
+
+func (b *styledTextBuilder) appendSlice(t StyledText, start, end int) {
+	runStart := 0
+	for _, run := range t.runs {
+		if run.end > start && runStart < end {
+			b.append(t.text[max(start, runStart):min(end, run.end)], run.style)
+		}
+		runStart = run.end
+	}
+}
+
+func (b *styledTextBuilder) result() StyledText {
+	return StyledText{b.text.String(), b.runs}
+}
@@ -0,0 +1,113 @@
+package sgr_test
+
+import (
+	"bytes"
+	"testing"
+
+	. "github.com/pamburus/go-tst/tst"
+
+	"github.com/pamburus/go-ansi-esc/sgr"
+)
+
+func TestStyledText(tt *testing.T) {
+	t := New(tt)
+
+	bold := sgr.Style{Modes: sgr.Bold.ModeSet()}
+	red := sgr.Style{Foreground: sgr.Red.Color()}
+	blue := sgr.Style{Background: sgr.Blue.Color()}
+
+	text := sgr.NewStyledText("hello ", bold).Append("world", red)
+
+	t.Run("Build", func(t Test) {
+		t.Expect(text.Plain()).ToEqual("hello world")
+		t.Expect(text.Len()).ToEqual(11)
+		t.Expect(text.Runs()).ToEqual([]sgr.StyledRun{
+			{Start: 0, End: 6, Style: bold},
+			{Start: 6, End: 11, Style: red},
+		})
+		t.Expect(text.StyleAt(7)).ToEqual(red)
+		t.Expect(sgr.StyledText{}.IsEmpty()).ToBeTrue()
+	})
+
+	t.Run("Merge", func(t Test) {
+		merged := sgr.NewStyledText("a", red).Append("", bold).Append("b", sgr.Style{Foreground: sgr.Red.Color()})
+		t.Expect(merged.Runs()).ToEqual([]sgr.StyledRun{{Start: 0, End: 2, Style: red}})
+	})
+
+	t.Run("Render", func(t Test) {
+		t.Expect(text.String()).ToEqual("\x1b[1mhello \x1b[31;22mworld\x1b[0m")
+		t.Expect(sgr.NewStyledText("plain", sgr.Style{}).String()).ToEqual("plain")
+		t.Expect(sgr.StyledText{}.String()).ToEqual("")
+	})
+
+	t.Run("Parse", func(t Test) {
+		parsed := sgr.ParseStyledText([]byte("\x1b[1mhello \x1b[22;31mwor\x1b[2Kld\x1b[39m!\x1b[0m"))
+		t.Expect(parsed.Plain()).ToEqual("hello world!")
+		t.Expect(parsed.Runs()).ToEqual([]sgr.StyledRun{
+			{Start: 0, End: 6, Style: bold},
+			{Start: 6, End: 11, Style: red},
+			{Start: 11, End: 12, Style: sgr.Style{}},
+		})
+		t.Expect(sgr.ParseStyledText(text.Bytes())).ToEqual(text)
+	})
+
+	t.Run("Slice", func(t Test) {
+		t.Expect(text.Slice(3, 8).Runs()).ToEqual([]sgr.StyledRun{
+			{Start: 0, End: 3, Style: bold},
+			{Start: 3, End: 5, Style: red},
+		})
+		t.Expect(text.Slice(7, 9)).ToEqual(sgr.NewStyledText("or", red))
+		t.Expect(text.Slice(4, 4).IsEmpty()).ToBeTrue()
+	})
+
+	t.Run("Concat", func(t Test) {
+		t.Expect(text.Slice(0, 6).Concat(text.Slice(6, 11))).ToEqual(text)
+		t.Expect(sgr.StyledText{}.Concat(text, sgr.NewStyledText("!", red)).Runs()).ToEqual([]sgr.StyledRun{
+			{Start: 0, End: 6, Style: bold},
+			{Start: 6, End: 12, Style: red},
+		})
+	})
+
+	t.Run("Insert", func(t Test) {
+		result := text.Insert(6, sgr.NewStyledText("big ", blue))
+		t.Expect(result.Plain()).ToEqual("hello big world")
+		t.Expect(result.Runs()).ToEqual([]sgr.StyledRun{
+			{Start: 0, End: 6, Style: bold},
+			{Start: 6, End: 10, Style: bold.WithOther(blue)},
+			{Start: 10, End: 15, Style: red},
+		})
+		t.Expect(text.Insert(0, sgr.NewStyledText(">", sgr.Style{})).Runs()[0]).ToEqual(sgr.StyledRun{Start: 0, End: 7, Style: bold})
+	})
+
+	t.Run("ReplaceAll", func(t Test) {
+		result := text.ReplaceAll("o", sgr.NewStyledText("0", blue))
+		t.Expect(result.Plain()).ToEqual("hell0 w0rld")
+		t.Expect(result.Runs()).ToEqual([]sgr.StyledRun{
+			{Start: 0, End: 4, Style: bold},
+			{Start: 4, End: 5, Style: bold.WithOther(blue)},
+			{Start: 5, End: 6, Style: bold},
+			{Start: 6, End: 7, Style: red},
+			{Start: 7, End: 8, Style: red.WithOther(blue)},
+			{Start: 8, End: 11, Style: red},
+		})
+		t.Expect(text.ReplaceAll("", sgr.NewStyledText("x", blue))).ToEqual(text)
+		t.Expect(text.ReplaceAll("xyz", sgr.NewStyledText("x", blue))).ToEqual(text)
+	})
+
+	t.Run("WithBaseStyle", func(t Test) {
+		t.Expect(text.WithBaseStyle(blue).Runs()).ToEqual([]sgr.StyledRun{
+			{Start: 0, End: 6, Style: blue.WithOther(bold)},
+			{Start: 6, End: 11, Style: blue.WithOther(red)},
+		})
+	})
+
+	t.Run("Writer", func(t Test) {
+		buf := bytes.NewBuffer(nil)
+		w := sgr.NewWriter(buf)
+		w.PushBackgroundColor(sgr.Blue)
+		t.Expect(w.WriteStyledText(text)).ToSucceed().AndResult().ToEqual(11)
+		w.PopBackgroundColor()
+		t.Expect(w.Flush()).ToSucceed()
+		t.Expect(buf.String()).ToEqual("\x1b[44;1mhello \x1b[31;22mworld\x1b[0m")
+	})
+}
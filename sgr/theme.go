@@ -0,0 +1,94 @@
+package sgr
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+)
+
+// ---
+
+// Theme maps role names to styles, so that styles used by an application can be customized by its users.
+//
+// Role names are dot-separated paths like "level.error", "key" or "punctuation".
+// Style lookup falls back to less specific roles by removing trailing path components,
+// so that "level" can define a common style for all "level.*" roles.
+//
+// Theme can be loaded from JSON where each style is either an object with "foreground", "background",
+// "underline-color" and "modes" fields using textual representations of Color and Mode values,
+// or a string in the form accepted by ParseStyle.
+type Theme map[string]Style
+
+// Style returns the style for the given role and true if it is found directly or using fallback to less specific roles.
+// Otherwise it returns zero Style and false.
+func (t Theme) Style(role string) (Style, bool) {
+	for {
+		if style, ok := t[role]; ok {
+			return style, true
+		}
+
+		i := strings.LastIndexByte(role, '.')
+		if i < 0 {
+			return Style{}, false
+		}
+
+		role = role[:i]
+	}
+}
+
+// MarshalJSON implements json.Marshaler interface.
+// Each style is represented as an object.
+func (t Theme) MarshalJSON() ([]byte, error) {
+	styles := make(map[string]themeStyle, len(t))
+	for role, style := range t {
+		styles[role] = themeStyle{
+			Foreground:     style.Foreground,
+			Background:     style.Background,
+			UnderlineColor: style.UnderlineColor,
+			Modes:          style.Modes.ModeList(),
+		}
+	}
+
+	return json.Marshal(styles)
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+// Each style can be represented either as an object or as a string.
+func (t *Theme) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	err := json.Unmarshal(data, &raw)
+	if err != nil {
+		return err
+	}
+
+	result := make(Theme, len(raw))
+	for role, data := range raw {
+		var style Style
+
+		if bytes.HasPrefix(bytes.TrimSpace(data), []byte(`"`)) {
+			err = json.Unmarshal(data, &style)
+		} else {
+			var ts themeStyle
+			err = json.Unmarshal(data, &ts)
+			style = Style{ts.Background, ts.Foreground, ts.UnderlineColor, ts.Modes.ModeSet()}
+		}
+		if err != nil {
+			return err
+		}
+
+		result[role] = style
+	}
+
+	*t = result
+
+	return nil
+}
+
+// ---
+
+type themeStyle struct {
+	Foreground     Color    `json:"foreground,omitempty"`
+	Background     Color    `json:"background,omitempty"`
+	UnderlineColor Color    `json:"underline-color,omitempty"`
+	Modes          ModeList `json:"modes,omitempty"`
+}
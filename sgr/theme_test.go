@@ -0,0 +1,67 @@
+package sgr_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	. "github.com/pamburus/go-tst/tst"
+
+	"github.com/pamburus/go-ansi-esc/sgr"
+)
+
+func TestTheme(tt *testing.T) {
+	t := New(tt)
+
+	theme := sgr.Theme{
+		"level":       {Modes: sgr.Bold.ModeSet()},
+		"level.error": {Foreground: sgr.BrightRed.Color(), Modes: sgr.Bold.ModeSet()},
+		"key":         {Foreground: sgr.Green.Color(), UnderlineColor: sgr.RGB(1, 2, 3).Color()},
+	}
+
+	t.Run("Style", func(t Test) {
+		t.Expect(theme.Style("level.error")).ToEqual(theme["level.error"], true)
+		t.Expect(theme.Style("level.error.fatal")).ToEqual(theme["level.error"], true)
+		t.Expect(theme.Style("level.info")).ToEqual(theme["level"], true)
+		t.Expect(theme.Style("levels")).ToEqual(sgr.Style{}, false)
+		t.Expect(sgr.Theme(nil).Style("key")).ToEqual(sgr.Style{}, false)
+	})
+
+	t.Run("JSON", func(t Test) {
+		data, err := json.Marshal(theme)
+		t.Expect(err).ToSucceed()
+		t.Expect(string(data)).ToEqual(
+			`{"key":{"foreground":"Green","underline-color":"#010203"},` +
+				`"level":{"modes":["Bold"]},` +
+				`"level.error":{"foreground":"BrightRed","modes":["Bold"]}}`,
+		)
+
+		var loaded sgr.Theme
+		t.Expect(json.Unmarshal(data, &loaded)).ToSucceed()
+		t.Expect(loaded).ToEqual(theme)
+
+		t.Expect(json.Unmarshal([]byte(`{"key": "green ul:#010203", "level": {"modes": ["bold"]}}`), &loaded)).ToSucceed()
+		t.Expect(loaded).ToEqual(sgr.Theme{"key": theme["key"], "level": theme["level"]})
+
+		t.Expect(json.Unmarshal([]byte(`{"key": {"foreground": "purple"}}`), &loaded)).ToFailWith(sgr.ErrInvalidColorText{})
+		t.Expect(json.Unmarshal([]byte(`{"key": {"modes": ["loud"]}}`), &loaded)).ToFailWith(sgr.ErrInvalidModeText{})
+		t.Expect(json.Unmarshal([]byte(`{"key": "loud"}`), &loaded)).ToFailWith(sgr.ErrInvalidStyleText{})
+		t.Expect(json.Unmarshal([]byte(`[]`), &loaded)).ToFail()
+	})
+
+	t.Run("Writer", func(t Test) {
+		buf := bytes.NewBuffer(nil)
+		w := sgr.NewWriter(buf, sgr.WithTheme(theme))
+		w.PushRole("level.error")
+		t.Expect(w.WriteString("ERR")).ToSucceed()
+		w.PushRole("unknown")
+		t.Expect(w.WriteString("|")).ToSucceed()
+		w.PopRole()
+		w.PopRole()
+		w.PushRole("key")
+		t.Expect(w.WriteString("k")).ToSucceed()
+		w.PopRole()
+		t.Expect(w.Flush()).ToSucceed()
+		t.Expect(buf.String()).ToEqual("\x1b[91;1mERR|\x1b[32;58;2;1;2;3;22mk\x1b[0m")
+	})
+}
@@ -0,0 +1,101 @@
+package sgr
+
+import (
+	"bytes"
+	"unicode/utf8"
+)
+
+// ---
+
+// ScanTokens is a split function for a bufio.Scanner that splits a byte stream into tokens
+// each of which is either a single escape sequence or a piece of text not containing escape sequences.
+// Text tokens never split a valid UTF-8 encoded character unless the end of the stream is reached.
+// Use IsEscape to distinguish escape sequences from text and ParseSequence to parse CSI/SGR sequences.
+func ScanTokens(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	if len(data) == 0 {
+		return 0, nil, nil
+	}
+
+	if data[0] == esc {
+		n, complete := escapeLen(data)
+		if !complete && !atEOF {
+			return 0, nil, nil
+		}
+
+		return n, data[:n], nil
+	}
+
+	n := bytes.IndexByte(data, esc)
+	if n < 0 {
+		n = len(data)
+		if !atEOF {
+			n = completeRunesLen(data)
+			if n == 0 {
+				return 0, nil, nil
+			}
+		}
+	}
+
+	return n, data[:n], nil
+}
+
+// IsEscape returns true if token starts with ESC character.
+func IsEscape(token []byte) bool {
+	return len(token) != 0 && token[0] == esc
+}
+
+// ---
+
+// escapeLen returns the length of the escape sequence at the beginning of b and true if it is complete.
+// It expects b to start with ESC and returns len(b) and false in case the sequence is not terminated.
+func escapeLen(b []byte) (int, bool) {
+	if len(b) < 2 {
+		return len(b), false
+	}
+
+	switch b[1] {
+	case '[':
+		for i := 2; i < len(b); i++ {
+			if b[i] >= 0x40 && b[i] <= 0x7E {
+				return i + 1, true
+			}
+		}
+
+		return len(b), false
+	case ']', 'P', '_', '^', 'X':
+		for i := 2; i < len(b); i++ {
+			switch {
+			case b[i] == bel:
+				return i + 1, true
+			case b[i] == esc && i+1 < len(b) && b[i+1] == '\\':
+				return i + 2, true
+			}
+		}
+
+		return len(b), false
+	default:
+		return 2, true
+	}
+}
+
+// completeRunesLen returns the length of the longest prefix of b that does not end with an incomplete UTF-8 sequence.
+func completeRunesLen(b []byte) int {
+	for i := len(b) - 1; i >= 0 && i >= len(b)-utf8.UTFMax; i-- {
+		if utf8.RuneStart(b[i]) {
+			if utf8.FullRune(b[i:]) {
+				return len(b)
+			}
+
+			return i
+		}
+	}
+
+	return len(b)
+}
+
+// ---
+
+const (
+	esc = 0x1b
+	bel = 0x07
+)
@@ -0,0 +1,54 @@
+package sgr_test
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+
+	. "github.com/pamburus/go-tst/tst"
+
+	"github.com/pamburus/go-ansi-esc/sgr"
+)
+
+func TestScanTokens(tt *testing.T) {
+	t := New(tt)
+
+	scan := func(input string) []string {
+		scanner := bufio.NewScanner(strings.NewReader(input))
+		scanner.Split(sgr.ScanTokens)
+
+		var tokens []string
+		for scanner.Scan() {
+			tokens = append(tokens, scanner.Text())
+		}
+		t.Expect(scanner.Err()).ToSucceed()
+
+		return tokens
+	}
+
+	t.Run("Mixed", func(t Test) {
+		t.Expect(
+			scan("a\x1b[31mbc\x1b]8;;http://x\x1b\\d\x1b]0;t\x07\x1b7é\x1b[2K\x1b[1"),
+		).ToEqual(
+			[]string{"a", "\x1b[31m", "bc", "\x1b]8;;http://x\x1b\\", "d", "\x1b]0;t\x07", "\x1b7", "é", "\x1b[2K", "\x1b[1"},
+		)
+	})
+
+	t.Run("Incomplete", func(t Test) {
+		t.Expect(sgr.ScanTokens([]byte("\x1b[3"), false)).ToEqual(0, []byte(nil), nil)
+		t.Expect(sgr.ScanTokens([]byte("\x1b]8;;"), false)).ToEqual(0, []byte(nil), nil)
+		t.Expect(sgr.ScanTokens([]byte("\x1b"), false)).ToEqual(0, []byte(nil), nil)
+		t.Expect(sgr.ScanTokens([]byte("\x1b"), true)).ToEqual(1, []byte("\x1b"), nil)
+		t.Expect(sgr.ScanTokens([]byte("ab\xc3"), false)).ToEqual(2, []byte("ab"), nil)
+		t.Expect(sgr.ScanTokens([]byte("\xc3"), false)).ToEqual(0, []byte(nil), nil)
+		t.Expect(sgr.ScanTokens([]byte("\xc3"), true)).ToEqual(1, []byte("\xc3"), nil)
+		t.Expect(sgr.ScanTokens([]byte("ab\xff"), false)).ToEqual(3, []byte("ab\xff"), nil)
+		t.Expect(sgr.ScanTokens(nil, true)).ToEqual(0, []byte(nil), nil)
+	})
+
+	t.Run("IsEscape", func(t Test) {
+		t.Expect(sgr.IsEscape([]byte("\x1b[1m"))).ToBeTrue()
+		t.Expect(sgr.IsEscape([]byte("a"))).ToBeFalse()
+		t.Expect(sgr.IsEscape(nil)).ToBeFalse()
+	})
+}
@@ -0,0 +1,107 @@
+package sgr
+
+import "fmt"
+
+// ---
+
+// Complete set of valid Optimization values.
+const (
+	// OptimizeIncremental makes Writer emit only commands for attributes that have changed,
+	// or a single reset command when all attributes are changed to terminal defaults.
+	OptimizeIncremental Optimization = iota
+	// OptimizeSize makes Writer compare incremental transition with resetting all attributes
+	// and re-applying non-default ones, and choose the one that produces less bytes.
+	OptimizeSize
+)
+
+// Optimization defines a strategy Writer uses to plan transitions between SGR states.
+type Optimization uint8
+
+// String returns textual description of o that can be used for debugging or logging purposes.
+func (o Optimization) String() string {
+	if name, ok := optimizationNames[o]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("<!0x%02x>", uint8(o))
+}
+
+// Validate check that o has a valid value.
+func (o Optimization) Validate() error {
+	if _, ok := optimizationNames[o]; !ok {
+		return ErrInvalidOptimizationValue{o}
+	}
+
+	return nil
+}
+
+// ---
+
+// transition appends commands needed to bring a terminal from one state to another to seq and returns modified seq.
+func (o Optimization) transition(seq Sequence, from, to state) Sequence {
+	if from == to {
+		return seq
+	}
+
+	if to == defaultState {
+		return append(seq, ResetAll)
+	}
+
+	i := len(seq)
+	seq = incrementalTransition(seq, from, to)
+	if o != OptimizeSize {
+		return seq
+	}
+
+	j := len(seq)
+	seq = append(seq, ResetAll)
+	seq = incrementalTransition(seq, defaultState, to)
+
+	if renderedLen(seq[j:]) < renderedLen(seq[i:j]) {
+		return append(seq[:i], seq[j:]...)
+	}
+
+	return seq[:j]
+}
+
+// ---
+
+func incrementalTransition(seq Sequence, from, to state) Sequence {
+	if to.bgc != from.bgc {
+		seq = append(seq, setBackgroundColor(to.bgc))
+	}
+	if to.fgc != from.fgc {
+		seq = append(seq, setForegroundColor(to.fgc))
+	}
+	if to.ulc != from.ulc {
+		seq = append(seq, setUnderlineColor(to.ulc))
+	}
+	if to.modes != from.modes {
+		seq = from.modes.Diff(to.modes).ToCommands(seq)
+	}
+
+	return seq
+}
+
+// renderedLen returns the number of bytes seq would take in the rendered form
+// excluding the leading CSI and trailing final byte as they are the same for any non-empty sequence.
+func renderedLen(seq Sequence) int {
+	n := 0
+	for _, c := range seq {
+		if l := c.renderedLen(); l != 0 {
+			if n != 0 {
+				n++
+			}
+			n += l
+		}
+	}
+
+	return n
+}
+
+// ---
+
+var optimizationNames = map[Optimization]string{
+	OptimizeIncremental: "incremental",
+	OptimizeSize:        "size",
+}
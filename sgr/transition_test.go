@@ -0,0 +1,25 @@
+package sgr_test
+
+import (
+	"testing"
+
+	. "github.com/pamburus/go-tst/tst"
+
+	"github.com/pamburus/go-ansi-esc/sgr"
+)
+
+func TestOptimization(tt *testing.T) {
+	t := New(tt)
+
+	t.Run("String", func(t Test) {
+		t.Expect(sgr.OptimizeIncremental.String()).ToEqual("incremental")
+		t.Expect(sgr.OptimizeSize.String()).ToEqual("size")
+		t.Expect(sgr.Optimization(7).String()).ToEqual("<!0x07>")
+	})
+
+	t.Run("Validate", func(t Test) {
+		t.Expect(sgr.OptimizeIncremental.Validate()).ToSucceed()
+		t.Expect(sgr.OptimizeSize.Validate()).ToSucceed()
+		t.Expect(sgr.Optimization(7).Validate()).ToFailWith(sgr.ErrInvalidOptimizationValue{Value: 7})
+	})
+}
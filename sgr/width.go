@@ -0,0 +1,156 @@
+package sgr
+
+import (
+	"unicode/utf8"
+
+	"github.com/rivo/uniseg"
+	"golang.org/x/text/width"
+)
+
+// ---
+
+// Width returns the number of terminal cells needed to display b skipping all escape sequences.
+// East Asian Ambiguous characters are considered narrow.
+func Width(b []byte) int {
+	return AmbiguousNarrow.Width(b)
+}
+
+// ---
+
+// Complete set of valid AmbiguousWidth values.
+const (
+	// AmbiguousNarrow makes East Asian Ambiguous characters occupy a single cell like most terminals do by default.
+	AmbiguousNarrow AmbiguousWidth = iota
+	// AmbiguousWide makes East Asian Ambiguous characters occupy two cells like terminals configured for CJK locales do.
+	AmbiguousWide
+)
+
+// AmbiguousWidth is a policy defining the width of East Asian Ambiguous characters like "±", "Ω" or "①".
+type AmbiguousWidth uint8
+
+// Width returns the number of terminal cells needed to display b skipping all escape sequences.
+//
+// Text is measured by extended grapheme clusters, so that combining marks, zero-width joiner sequences
+// and variation selectors do not add cells, East Asian Wide and Fullwidth characters and emoji occupy two cells,
+// and East Asian Ambiguous characters occupy the number of cells defined by a.
+func (a AmbiguousWidth) Width(b []byte) int {
+	n := 0
+	for len(b) != 0 {
+		if b[0] == esc {
+			l, _ := escapeLen(b)
+			b = b[l:]
+
+			continue
+		}
+
+		var cluster []byte
+		var w int
+		cluster, b, w, _ = uniseg.FirstGraphemeCluster(b, -1)
+		n += a.clusterWidth(cluster, w)
+	}
+
+	return n
+}
+
+// clusterWidth adjusts width w of the grapheme cluster reported by uniseg according to the policy.
+func (a AmbiguousWidth) clusterWidth(cluster []byte, w int) int {
+	if a == AmbiguousWide && w == 1 {
+		r, _ := utf8.DecodeRune(cluster)
+		if width.LookupRune(r).Kind() == width.EastAsianAmbiguous {
+			return 2
+		}
+	}
+
+	return w
+}
+
+// ---
+
+// NewWidthCounter constructs a new WidthCounter using the given policy for East Asian Ambiguous characters.
+func NewWidthCounter(ambiguous AmbiguousWidth) *WidthCounter {
+	return &WidthCounter{ambiguous: ambiguous}
+}
+
+// WidthCounter is a streaming equivalent of Width.
+// It is an io.Writer that measures all data written to it, which can be split at arbitrary positions,
+// including positions inside escape sequences, UTF-8 encoded characters or grapheme clusters.
+type WidthCounter struct {
+	ambiguous AmbiguousWidth
+	pending   []byte
+	tail      []byte
+	width     int
+}
+
+// Write measures b and adds the result to the accumulated width. It never fails.
+func (c *WidthCounter) Write(b []byte) (int, error) {
+	c.pending = append(c.pending, b...)
+
+	data := c.pending
+	for {
+		n, token, _ := ScanTokens(data, false)
+		if n == 0 {
+			break
+		}
+		data = data[n:]
+
+		if IsEscape(token) {
+			c.commit()
+		} else {
+			c.add(token)
+		}
+	}
+
+	c.pending = append(c.pending[:0], data...)
+
+	return len(b), nil
+}
+
+// WriteString is the same as Write but accepts a string.
+func (c *WidthCounter) WriteString(s string) (int, error) {
+	return c.Write([]byte(s))
+}
+
+// Width returns the number of terminal cells needed to display all data written so far.
+// The last grapheme cluster is counted as complete even if it may be extended by subsequent writes.
+// Incomplete escape sequences at the end are not counted.
+func (c *WidthCounter) Width() int {
+	n := c.width
+	if len(c.tail) != 0 {
+		cluster, _, w, _ := uniseg.FirstGraphemeCluster(c.tail, -1)
+		n += c.ambiguous.clusterWidth(cluster, w)
+	}
+
+	return n
+}
+
+// Reset resets the accumulated width to zero and discards all pending data.
+func (c *WidthCounter) Reset() {
+	c.pending = c.pending[:0]
+	c.tail = c.tail[:0]
+	c.width = 0
+}
+
+// add measures all complete grapheme clusters of the text consisting of the tail and b
+// and keeps the last one as the new tail because it can be extended by subsequent data.
+func (c *WidthCounter) add(b []byte) {
+	c.tail = append(c.tail, b...)
+
+	text := c.tail
+	for {
+		cluster, rest, w, _ := uniseg.FirstGraphemeCluster(text, -1)
+		if len(rest) == 0 {
+			break
+		}
+
+		c.width += c.ambiguous.clusterWidth(cluster, w)
+		text = rest
+	}
+
+	c.tail = append(c.tail[:0], text...)
+}
+
+// commit measures the tail as a complete grapheme cluster.
+func (c *WidthCounter) commit() {
+	c.width = c.Width()
+	c.tail = c.tail[:0]
+}
@@ -0,0 +1,71 @@
+package sgr_test
+
+import (
+	"testing"
+
+	. "github.com/pamburus/go-tst/tst"
+
+	"github.com/pamburus/go-ansi-esc/sgr"
+)
+
+func TestWidth(tt *testing.T) {
+	t := New(tt)
+
+	cases := []struct {
+		name   string
+		text   string
+		narrow int
+		wide   int
+	}{
+		{"Empty", "", 0, 0},
+		{"ASCII", "hello", 5, 5},
+		{"SGR", "\x1b[1;31mhello\x1b[0m", 5, 5},
+		{"OSC", "\x1b]8;;https://example.com\x1b\\link\x1b]8;;\x07", 4, 4},
+		{"Wide", "日本語", 6, 6},
+		{"Fullwidth", "ＡＢ", 4, 4},
+		{"Combining", "e\u0301e\u0308\u0323", 2, 2},
+		{"ZWJ", "\U0001F469\u200D\U0001F469\u200D\U0001F467", 2, 2},
+		{"Flag", "🇯🇵", 2, 2},
+		{"EmojiPresentation", "\u263A\uFE0F", 2, 2},
+		{"Ambiguous", "±Ω", 2, 4},
+		{"Mixed", "\x1b[32ma\x1b[0m\u65E5e\u0301\u00B1", 5, 6},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t Test) {
+			t.Expect(sgr.Width([]byte(tc.text))).ToEqual(tc.narrow)
+			t.Expect(sgr.AmbiguousNarrow.Width([]byte(tc.text))).ToEqual(tc.narrow)
+			t.Expect(sgr.AmbiguousWide.Width([]byte(tc.text))).ToEqual(tc.wide)
+		})
+	}
+
+	t.Run("Counter", func(t Test) {
+		for _, tc := range cases {
+			t.Run(tc.name, func(t Test) {
+				for _, ambiguous := range []sgr.AmbiguousWidth{sgr.AmbiguousNarrow, sgr.AmbiguousWide} {
+					expected := ambiguous.Width([]byte(tc.text))
+					for split := 0; split <= len(tc.text); split++ {
+						c := sgr.NewWidthCounter(ambiguous)
+						t.Expect(c.WriteString(tc.text[:split])).ToSucceed()
+						t.Expect(c.Write([]byte(tc.text[split:]))).ToSucceed()
+						t.Expect(c.Width()).ToEqual(expected)
+					}
+				}
+			})
+		}
+
+		t.Run("Incremental", func(t Test) {
+			c := sgr.NewWidthCounter(sgr.AmbiguousNarrow)
+			t.Expect(c.WriteString("ab\x1b[3")).ToSucceed()
+			t.Expect(c.Width()).ToEqual(2)
+			t.Expect(c.WriteString("1m日\xe6")).ToSucceed()
+			t.Expect(c.Width()).ToEqual(4)
+			t.Expect(c.WriteString("\x9c\xac")).ToSucceed()
+			t.Expect(c.Width()).ToEqual(6)
+			c.Reset()
+			t.Expect(c.Width()).ToEqual(0)
+			t.Expect(c.WriteString("x")).ToSucceed()
+			t.Expect(c.Width()).ToEqual(1)
+		})
+	})
+}
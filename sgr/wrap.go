@@ -0,0 +1,219 @@
+package sgr
+
+import (
+	"strings"
+
+	"github.com/rivo/uniseg"
+)
+
+// ---
+
+// Wrap reflows b containing CSI/SGR sequences to fit into the given number of terminal cells per line.
+// See StyledText.Wrap for details.
+// Each line of the result is self-contained: it starts from the terminal default style,
+// reopens the style active at the line start and resets the style at the line end if needed.
+// Lines are separated by a single line feed character. Escape sequences other than SGR are dropped.
+func Wrap(b []byte, cols int, options ...WrapOption) []byte {
+	lines := ParseStyledText(b).Wrap(cols, options...)
+
+	result := make([]byte, 0, len(b)+len(lines)*8)
+	for i, line := range lines {
+		if i != 0 {
+			result = append(result, '\n')
+		}
+		result = line.Render(result)
+	}
+
+	return result
+}
+
+// ---
+
+// WrapOption is an option that can be passed to Wrap or StyledText.Wrap to customize wrapping.
+type WrapOption func(*wrapOptions)
+
+// WithHyphenation returns a WrapOption that allows breaking words at any grapheme cluster boundary
+// inserting a hyphen at the break, so that lines are filled more evenly.
+// Without hyphenation, words are broken only if they do not fit into a whole line, and no hyphen is inserted.
+func WithHyphenation() WrapOption {
+	return func(o *wrapOptions) {
+		o.hyphen = "-"
+	}
+}
+
+// WithHangingIndent returns a WrapOption that indents all lines of each paragraph except the first one
+// by the given number of spaces.
+// A paragraph is a piece of text starting at the beginning of the text or after an explicit line break.
+func WithHangingIndent(indent int) WrapOption {
+	return func(o *wrapOptions) {
+		o.indent = max(indent, 0)
+	}
+}
+
+// ---
+
+// Wrap reflows t to fit into the given number of terminal cells per line and returns the resulting lines.
+//
+// Lines are broken at line break opportunities defined by the Unicode Line Breaking Algorithm
+// and at explicit line breaks. White space at the end of broken lines is removed.
+// Words that do not fit into a whole line are broken at grapheme cluster boundaries.
+// Each line contains at least one grapheme cluster, even if it does not fit.
+func (t StyledText) Wrap(cols int, options ...WrapOption) []StyledText {
+	if t.IsEmpty() {
+		return nil
+	}
+
+	w := wrapper{t: t, cols: cols, empty: true, first: true}
+	for _, option := range options {
+		option(&w.o)
+	}
+
+	text := t.text
+	state := -1
+	offset := 0
+	for text != "" {
+		var segment string
+		segment, text, _, state = uniseg.FirstLineSegmentInString(text, state)
+
+		content := strings.TrimRight(segment, "\r\n\v\f\u0085\u2028\u2029")
+		core := strings.TrimRight(content, " \t")
+
+		w.place(offset, offset+len(core))
+		w.space = [2]int{offset + len(core), offset + len(content)}
+
+		if len(content) != len(segment) {
+			w.newLine(true)
+		}
+
+		offset += len(segment)
+	}
+
+	w.lines = append(w.lines, w.line.result())
+
+	return w.lines
+}
+
+// ---
+
+type wrapOptions struct {
+	hyphen string
+	indent int
+}
+
+type wrapper struct {
+	t     StyledText
+	o     wrapOptions
+	cols  int
+	lines []StyledText
+	line  styledTextBuilder
+	width int
+	empty bool
+	first bool
+	space [2]int
+}
+
+// place places the word between start and end offsets to the current line,
+// breaking lines and the word itself if needed.
+func (w *wrapper) place(start, end int) {
+	for start != end {
+		avail := w.cols
+		if !w.first {
+			avail -= w.o.indent
+		}
+
+		space := 0
+		if !w.empty {
+			space = w.measure(w.space[0], w.space[1])
+		}
+
+		if w.width+space+w.measure(start, end) <= avail {
+			w.flushSpace()
+			w.append(start, end)
+
+			return
+		}
+
+		if w.o.hyphen != "" || w.empty {
+			room := avail - w.width - space
+			if w.o.hyphen != "" {
+				room -= Width([]byte(w.o.hyphen))
+			}
+
+			cut, n := w.fit(start, end, room)
+			minimum := 2
+			if w.empty {
+				minimum = 1
+			}
+
+			if n < minimum && w.empty {
+				_, rest, _, _ := uniseg.FirstGraphemeClusterInString(w.t.text[start:end], -1)
+				cut, n = end-len(rest), 1
+			}
+
+			if n >= minimum {
+				w.flushSpace()
+				w.append(start, cut)
+				if w.o.hyphen != "" && cut != end {
+					w.line.append(w.o.hyphen, w.t.StyleAt(cut-1))
+				}
+				start = cut
+				if start == end {
+					return
+				}
+			}
+		}
+
+		w.newLine(false)
+	}
+}
+
+// fit returns the end offset and the number of grapheme clusters of the longest prefix
+// of the text between start and end offsets that fits into the given number of cells.
+func (w *wrapper) fit(start, end, cols int) (int, int) {
+	text := w.t.text[start:end]
+	width := 0
+	n := 0
+	for text != "" {
+		_, rest, cw, _ := uniseg.FirstGraphemeClusterInString(text, -1)
+		if width+cw > cols {
+			break
+		}
+		width += cw
+		text = rest
+		n++
+	}
+
+	return end - len(text), n
+}
+
+func (w *wrapper) measure(start, end int) int {
+	return Width([]byte(w.t.text[start:end]))
+}
+
+func (w *wrapper) append(start, end int) {
+	w.line.appendSlice(w.t, start, end)
+	w.width += w.measure(start, end)
+	w.empty = false
+}
+
+func (w *wrapper) flushSpace() {
+	if !w.empty {
+		w.append(w.space[0], w.space[1])
+	}
+	w.space = [2]int{}
+}
+
+// newLine finishes the current line and starts a new one.
+// If explicit is true, the new line starts a new paragraph.
+func (w *wrapper) newLine(explicit bool) {
+	w.lines = append(w.lines, w.line.result())
+	w.line = styledTextBuilder{}
+	w.width = 0
+	w.empty = true
+	w.space = [2]int{}
+	w.first = explicit
+
+	if !explicit && w.o.indent != 0 {
+		w.line.append(spaces(w.o.indent), Style{})
+	}
+}
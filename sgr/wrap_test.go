@@ -0,0 +1,71 @@
+package sgr_test
+
+import (
+	"testing"
+
+	. "github.com/pamburus/go-tst/tst"
+
+	"github.com/pamburus/go-ansi-esc/sgr"
+)
+
+func TestWrap(tt *testing.T) {
+	t := New(tt)
+
+	plain := func(lines []sgr.StyledText) []string {
+		result := make([]string, len(lines))
+		for i, line := range lines {
+			result[i] = line.Plain()
+		}
+
+		return result
+	}
+
+	wrap := func(text string, cols int, options ...sgr.WrapOption) []string {
+		return plain(sgr.NewStyledText(text, sgr.Style{}).Wrap(cols, options...))
+	}
+
+	t.Run("Words", func(t Test) {
+		t.Expect(wrap("the quick  brown fox", 10)).ToEqual([]string{"the quick", "brown fox"})
+		t.Expect(wrap("the quick brown fox", 100)).ToEqual([]string{"the quick brown fox"})
+		t.Expect(wrap("self-contained line", 8)).ToEqual([]string{"self-", "containe", "d line"})
+		t.Expect(wrap("", 8)).ToEqual([]string{})
+	})
+
+	t.Run("LongWords", func(t Test) {
+		t.Expect(wrap("abcdefghij", 4)).ToEqual([]string{"abcd", "efgh", "ij"})
+		t.Expect(wrap("abc", 0)).ToEqual([]string{"a", "b", "c"})
+		t.Expect(wrap("日本語 テキスト", 6)).ToEqual([]string{"日本語", "テキス", "ト"})
+		t.Expect(wrap("日本", 1)).ToEqual([]string{"日", "本"})
+	})
+
+	t.Run("Hyphenation", func(t Test) {
+		t.Expect(wrap("aa bbbbbbb", 6, sgr.WithHyphenation())).ToEqual([]string{"aa bb-", "bbbbb"})
+		t.Expect(wrap("aaa bbbbbb", 6, sgr.WithHyphenation())).ToEqual([]string{"aaa", "bbbbbb"})
+		t.Expect(wrap("abcdefghij", 4, sgr.WithHyphenation())).ToEqual([]string{"abc-", "def-", "ghij"})
+	})
+
+	t.Run("HangingIndent", func(t Test) {
+		t.Expect(wrap("one two three four\nfive six seven", 9, sgr.WithHangingIndent(2))).
+			ToEqual([]string{"one two", "  three", "  four", "five six", "  seven"})
+	})
+
+	t.Run("LineBreaks", func(t Test) {
+		t.Expect(wrap("a b\r\nc d\n\ne", 10)).ToEqual([]string{"a b", "c d", "", "e"})
+		t.Expect(wrap("a\n", 10)).ToEqual([]string{"a", ""})
+	})
+
+	t.Run("Styles", func(t Test) {
+		red := sgr.Style{Foreground: sgr.Red.Color()}
+		text := sgr.NewStyledText("hello ", sgr.Style{}).Append("brave new", red).Append(" world", sgr.Style{})
+		lines := text.Wrap(11, sgr.WithHyphenation())
+		t.Expect(lines).ToEqual([]sgr.StyledText{
+			sgr.NewStyledText("hello ", sgr.Style{}).Append("brave", red),
+			sgr.NewStyledText("new", red).Append(" world", sgr.Style{}),
+		})
+
+		t.Expect(string(sgr.Wrap([]byte("\x1b[31mhello world\x1b[0m again"), 6))).
+			ToEqual("\x1b[31mhello\x1b[0m\n\x1b[31mworld\x1b[0m\nagain")
+		t.Expect(string(sgr.Wrap([]byte("\x1b[1mabcdef\x1b[0m"), 4, sgr.WithHyphenation(), sgr.WithHangingIndent(1)))).
+			ToEqual("\x1b[1mabc-\x1b[0m\n \x1b[1mdef\x1b[0m")
+	})
+}
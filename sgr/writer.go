@@ -1,16 +1,44 @@
 package sgr
 
-import "io"
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
 
 // NewWriter constructs a new Writer over the given target writer.
-func NewWriter(target io.Writer) *Writer {
-	p := &Writer{target: target}
+func NewWriter(target io.Writer, options ...WriterOption) *Writer {
+	return newWriter([]sink{{target: target}}, options)
+}
+
+// NewTeeWriter constructs a new Writer that duplicates its output to all the given targets.
+// Style changes are made once, and each target keeps track of its own emitted state and has its own color profile,
+// so that each target receives sequences that are optimal and correct for it.
+func NewTeeWriter(targets []Target, options ...WriterOption) *Writer {
+	sinks := make([]sink, len(targets))
+	for i, target := range targets {
+		sinks[i] = sink{target: target.Writer, profile: target.Profile}
+	}
+
+	return newWriter(sinks, options)
+}
+
+func newWriter(sinks []sink, options []WriterOption) *Writer {
+	p := &Writer{sinks: sinks}
+	for i := range p.sinks {
+		p.sinks[i].upstream = defaultState
+	}
+	for _, option := range options {
+		option(p)
+	}
 	p.head = defaultState
-	p.upstream = defaultState
 	p.stack.bgc = make([]Color, 0, 8)
 	p.stack.fgc = make([]Color, 0, 8)
 	p.stack.ulc = make([]Color, 0, 8)
 	p.stack.modes = make([]ModeSet, 0, 8)
+	p.stack.links = make([]Hyperlink, 0, 4)
 	p.scratchCommands = make(Sequence, 0, 8)
 	p.scratchBytes = make([]byte, 128)
 
@@ -24,27 +52,166 @@ func NewWriter(target io.Writer) *Writer {
 // it calculates an renders needed sequence only when its Write or Flush method is called.
 // Until that only current values are remembered.
 type Writer struct {
-	target   io.Writer
-	head     state
-	upstream state
-	stack    struct {
-		bgc   []Color
-		fgc   []Color
-		ulc   []Color
-		modes []ModeSet
-	}
+	sinks           []sink
+	head            state
+	link            Hyperlink
+	stack           stacks
 	scratchCommands Sequence
 	scratchBytes    []byte
+	scratchText     []byte
+	scratchArgs     []any
+	styled          []styledSegment
+	optimization    Optimization
+	theme           Theme
+	background      Background
+}
+
+// WriterOption is an option that can be passed to NewWriter to customize Writer behavior.
+type WriterOption func(*Writer)
+
+// WithOptimization returns a WriterOption that sets the strategy for planning transitions between SGR states.
+// Default is OptimizeIncremental, which is also used for any invalid value, see Optimization.Validate.
+func WithOptimization(optimization Optimization) WriterOption {
+	return func(w *Writer) {
+		w.optimization = optimization
+	}
+}
+
+// WithColorProfile returns a WriterOption that sets the color profile for all target writers.
+// Default is ColorProfileTrueColor.
+func WithColorProfile(profile ColorProfile) WriterOption {
+	return func(w *Writer) {
+		for i := range w.sinks {
+			w.sinks[i].profile = profile
+		}
+	}
+}
+
+// WithBuffer returns a WriterOption that makes Writer accumulate SGR sequences and written data
+// in a buffer for each target writer instead of writing them immediately.
+// The buffer is written to the target writer when its size reaches the given threshold or Flush is called.
+func WithBuffer(threshold int) WriterOption {
+	return func(w *Writer) {
+		for i := range w.sinks {
+			w.sinks[i].threshold = max(threshold, 1)
+			w.sinks[i].buf = make([]byte, 0, max(threshold, 1))
+		}
+	}
+}
+
+// WithLineFlush returns a WriterOption that makes buffered Writer also write the buffer to the target writer
+// each time written data contains a new line character.
+// It has no effect if Writer is not buffered using WithBuffer option.
+func WithLineFlush() WriterOption {
+	return func(w *Writer) {
+		for i := range w.sinks {
+			w.sinks[i].lineFlush = true
+		}
+	}
 }
 
-// Reset resets current SGR state to terminal defaults.
+// WithTheme returns a WriterOption that sets the theme used to resolve roles passed to PushRole method.
+func WithTheme(theme Theme) WriterOption {
+	return func(w *Writer) {
+		w.theme = theme
+	}
+}
+
+// WithBackground returns a WriterOption that sets the kind of terminal background used to resolve AdaptiveColor values.
+// Default is BackgroundDark.
+// Use DetectBackground or osc.QueryBackground to detect the actual background.
+func WithBackground(background Background) WriterOption {
+	return func(w *Writer) {
+		w.background = background
+	}
+}
+
+// Target is a target writer with its color profile.
+type Target struct {
+	Writer  io.Writer
+	Profile ColorProfile
+}
+
+// Snapshot returns a copy of the complete current SGR state including the stacks
+// that can be restored later using Restore method.
+func (w *Writer) Snapshot() WriterState {
+	marks := make([]sinkMark, len(w.sinks))
+	for i := range w.sinks {
+		marks[i] = w.sinks[i].mark()
+	}
+
+	return WriterState{w.head, w.link, w.stack.clone(), marks}
+}
+
+// Restore restores current SGR state and the stacks saved by Snapshot method.
+// It does not emit anything by itself, the needed changes are emitted lazily as usual
+// relative to the state that was actually emitted to the target writer,
+// so restoring any previous state never causes desynchronization with the terminal.
+// The same WriterState can be restored multiple times.
+func (w *Writer) Restore(s WriterState) {
+	w.head = s.head
+	w.link = s.link
+	w.stack.assign(s.stack)
+}
+
+// Rollback restores the state saved by Snapshot method like Restore does and additionally discards
+// all output buffered after the snapshot was taken, including SGR sequences,
+// so that the target writers receive the output as if nothing has been written after the snapshot.
+// It returns false and does nothing if any output has been written to any of the target writers
+// after the snapshot was taken, for example because Writer is not buffered, the buffer became full or Flush was called.
+func (w *Writer) Rollback(s WriterState) bool {
+	if len(s.marks) != len(w.sinks) {
+		return false
+	}
+
+	for i := range w.sinks {
+		if !w.sinks[i].canRollback(s.marks[i]) {
+			return false
+		}
+	}
+
+	for i := range w.sinks {
+		w.sinks[i].rollback(s.marks[i])
+	}
+	w.Restore(s)
+
+	return true
+}
+
+// Reset resets current SGR state to terminal defaults and removes current hyperlink.
 func (w *Writer) Reset() {
 	w.head = defaultState
+	w.link = Hyperlink{}
+}
+
+// Background returns the kind of terminal background used to resolve AdaptiveColor values.
+func (w *Writer) Background() Background {
+	return w.background
+}
+
+// Adapt returns the variant of the adaptive color for the background set using WithBackground option.
+// AdaptiveColor values passed to methods like PushForegroundColor are resolved the same way,
+// so Adapt is only needed to get the resulting Color for use elsewhere.
+func (w *Writer) Adapt(color AdaptiveColor) Color {
+	return color.Resolve(w.background)
+}
+
+// resolve converts color to Color resolving AdaptiveColor according to the background.
+// Both AdaptiveColor and *AdaptiveColor values are resolved.
+func (w *Writer) resolve(color IntoColor) Color {
+	switch adaptive := color.(type) {
+	case AdaptiveColor:
+		return adaptive.Resolve(w.background)
+	case *AdaptiveColor:
+		return adaptive.Resolve(w.background)
+	}
+
+	return color.Color()
 }
 
 // SetBackgroundColor changes current background color.
 func (w *Writer) SetBackgroundColor(color IntoColor) {
-	w.head.bgc = color.Color()
+	w.head.bgc = w.resolve(color)
 }
 
 // PushBackgroundColor changes background color and pushes old value to a stack
@@ -63,7 +230,7 @@ func (w *Writer) PopBackgroundColor() {
 
 // SetForegroundColor changes current foreground color.
 func (w *Writer) SetForegroundColor(color IntoColor) {
-	w.head.fgc = color.Color()
+	w.head.fgc = w.resolve(color)
 }
 
 // PushForegroundColor changes foreground color and pushes old value to a stack
@@ -82,7 +249,7 @@ func (w *Writer) PopForegroundColor() {
 
 // SetUnderlineColor changes current underline color.
 func (w *Writer) SetUnderlineColor(color IntoColor) {
-	w.head.ulc = color.Color()
+	w.head.ulc = w.resolve(color)
 }
 
 // PushUnderlineColor changes underline color and pushes old value to a stack
@@ -118,70 +285,395 @@ func (w *Writer) PopModes() {
 	w.stack.modes = w.stack.modes[:i]
 }
 
+// SetStyle changes current colors and modes using the given style.
+// Zero colors of the style do not change the corresponding current colors and its modes are added to the current modes.
+func (w *Writer) SetStyle(style Style) {
+	w.head = style.apply(w.head)
+}
+
+// PushStyle changes current colors and modes using the given style and pushes old values to the stacks
+// so that they can be restored using PopStyle method.
+func (w *Writer) PushStyle(style Style) {
+	w.stack.bgc = append(w.stack.bgc, w.head.bgc)
+	w.stack.fgc = append(w.stack.fgc, w.head.fgc)
+	w.stack.ulc = append(w.stack.ulc, w.head.ulc)
+	w.stack.modes = append(w.stack.modes, w.head.modes)
+	w.SetStyle(style)
+}
+
+// PopStyle restores old colors and modes that were saved at last PushStyle call.
+func (w *Writer) PopStyle() {
+	w.PopModes()
+	w.PopUnderlineColor()
+	w.PopForegroundColor()
+	w.PopBackgroundColor()
+}
+
+// PushRole changes current colors and modes using the style defined for the given role by the theme
+// set using WithTheme option and pushes old values to the stacks so that they can be restored using PopRole method.
+// If the theme does not define a style for the role, current colors and modes are not changed but still pushed.
+func (w *Writer) PushRole(role string) {
+	style, _ := w.theme.Style(role)
+	w.PushStyle(style)
+}
+
+// PopRole restores old colors and modes that were saved at last PushRole call.
+func (w *Writer) PopRole() {
+	w.PopStyle()
+}
+
+// SetHyperlink changes current hyperlink that is attached to all written text until it is changed again.
+// Zero Hyperlink removes current hyperlink.
+// Hyperlinks are not emitted to targets with ColorProfileNone.
+func (w *Writer) SetHyperlink(link Hyperlink) {
+	w.link = link
+}
+
+// PushHyperlink changes current hyperlink and pushes old value to a stack
+// so that it can be restored using PopHyperlink method.
+func (w *Writer) PushHyperlink(link Hyperlink) {
+	w.stack.links = append(w.stack.links, w.link)
+	w.SetHyperlink(link)
+}
+
+// PopHyperlink restores old hyperlink that was saved at last PushHyperlink call.
+func (w *Writer) PopHyperlink() {
+	i := len(w.stack.links) - 1
+	w.link = w.stack.links[i]
+	w.stack.links = w.stack.links[:i]
+}
+
 // Write flushes current style changes by generating CSI/SGR sequence and writing it
 // to the target writer and then finally writes the given data to it.
 func (w *Writer) Write(data []byte) (n int, err error) {
-	err = w.sync()
-	if err != nil {
-		return 0, err
+	w.normalize()
+	for i := range w.sinks {
+		err = w.sync(&w.sinks[i])
+		if err != nil {
+			return 0, err
+		}
+
+		n, err = w.sinks[i].write(data)
+		if err != nil {
+			return n, err
+		}
+	}
+
+	return len(data), nil
+}
+
+// WriteString does the same as Write but accepts a string.
+// It implements io.StringWriter interface.
+func (w *Writer) WriteString(s string) (n int, err error) {
+	w.normalize()
+	for i := range w.sinks {
+		err = w.sync(&w.sinks[i])
+		if err != nil {
+			return 0, err
+		}
+
+		n, err = w.sinks[i].writeString(s)
+		if err != nil {
+			return n, err
+		}
+	}
+
+	return len(s), nil
+}
+
+// WriteStyledText writes the plain text of t with styles of its runs applied on top of the current style.
+// It returns the number of bytes written excluding SGR sequences.
+func (w *Writer) WriteStyledText(t StyledText) (n int, err error) {
+	start := 0
+	for _, run := range t.runs {
+		w.PushStyle(run.style)
+		m, err := w.WriteString(t.text[start:run.end])
+		w.PopStyle()
+		n += m
+		if err != nil {
+			return n, err
+		}
+		start = run.end
+	}
+
+	return n, nil
+}
+
+// Print formats using the default formats for its operands like fmt.Print and writes the result.
+// Operands constructed with Styled are written with their own style applied only to them.
+// It returns the number of bytes written excluding SGR sequences.
+func (w *Writer) Print(a ...any) (n int, err error) {
+	return w.print(func(buf []byte, a ...any) []byte {
+		return fmt.Append(buf, a...)
+	}, a)
+}
+
+// Printf formats according to a format specifier like fmt.Printf and writes the result.
+// Operands constructed with Styled are written with their own style applied only to them.
+// It returns the number of bytes written excluding SGR sequences.
+func (w *Writer) Printf(format string, a ...any) (n int, err error) {
+	return w.print(func(buf []byte, a ...any) []byte {
+		return fmt.Appendf(buf, format, a...)
+	}, a)
+}
+
+// Println formats using the default formats for its operands like fmt.Println and writes the result.
+// Operands constructed with Styled are written with their own style applied only to them.
+// It returns the number of bytes written excluding SGR sequences.
+func (w *Writer) Println(a ...any) (n int, err error) {
+	return w.print(func(buf []byte, a ...any) []byte {
+		return fmt.Appendln(buf, a...)
+	}, a)
+}
+
+func (w *Writer) print(format func([]byte, ...any) []byte, a []any) (n int, err error) {
+	args := w.scratchArgs[0:0]
+	for _, arg := range a {
+		if v, ok := arg.(StyledValue); ok {
+			arg = boundStyledValue{v, w}
+		}
+		args = append(args, arg)
+	}
+
+	w.styled = w.styled[0:0]
+	buf := format(w.scratchText[0:0], args...)
+	styled := w.styled
+
+	clear(args)
+	w.scratchArgs = args[0:0]
+	w.scratchText = buf[0:0]
+
+	for len(buf) != 0 {
+		i := bytes.Index(buf, []byte(styledMarker))
+		if i < 0 || len(styled) == 0 {
+			i = len(buf)
+		}
+
+		if i != 0 {
+			m, err := w.Write(buf[:i])
+			n += m
+			if err != nil {
+				return n, err
+			}
+		}
+
+		if i == len(buf) {
+			break
+		}
+
+		buf = buf[i+len(styledMarker):]
+
+		w.PushStyle(styled[0].style)
+		m, err := w.Write(styled[0].text)
+		w.PopStyle()
+		n += m
+		if err != nil {
+			return n, err
+		}
+
+		styled = styled[1:]
 	}
 
-	return w.target.Write(data)
+	return n, nil
 }
 
 // Flush just flushes current style changes by generating CSI/SGR sequence and writing it
 // to the target writer.
+// If Writer is buffered, it also writes all buffered data to the target writer.
 // It is recommended to call Flush at the end of writing a line or a stream.
 func (w *Writer) Flush() error {
-	return w.sync()
-}
+	w.normalize()
+	for i := range w.sinks {
+		err := w.sync(&w.sinks[i])
+		if err != nil {
+			return err
+		}
 
-func (w *Writer) sync() error {
-	seq := w.scratchCommands[0:0]
-	buf := w.scratchBytes[0:0]
+		err = w.sinks[i].flush()
+		if err != nil {
+			return err
+		}
+	}
 
+	return nil
+}
+
+func (w *Writer) normalize() {
 	w.head.bgc = w.head.bgc.OrDefault()
 	w.head.fgc = w.head.fgc.OrDefault()
 	w.head.ulc = w.head.ulc.OrDefault()
+}
 
-	if w.head != w.upstream {
-		if w.head == defaultState {
-			seq = append(seq, ResetAll)
-			w.upstream = w.head
-		} else {
-			if w.head.bgc != w.upstream.bgc {
-				seq = append(seq, setBackgroundColor(w.head.bgc))
-				w.upstream.bgc = w.head.bgc
-			}
-			if w.head.fgc != w.upstream.fgc {
-				seq = append(seq, setForegroundColor(w.head.fgc))
-				w.upstream.fgc = w.head.fgc
+func (w *Writer) sync(s *sink) error {
+	seq := w.scratchCommands[0:0]
+	buf := w.scratchBytes[0:0]
+
+	head := s.profile.convertState(w.head)
+	seq = w.optimization.transition(seq, s.upstream, head)
+	s.upstream = head
+
+	link := w.link
+	if s.profile == ColorProfileNone {
+		link = Hyperlink{}
+	}
+	linkChanged := link != s.link
+	s.link = link
+
+	if len(seq) != 0 || linkChanged {
+		if s.buffered() {
+			s.buf = seq.Render(s.buf)
+			if linkChanged {
+				s.buf = link.Render(s.buf)
 			}
-			if w.head.ulc != w.upstream.ulc {
-				seq = append(seq, setUnderlineColor(w.head.ulc))
-				w.upstream.ulc = w.head.ulc
+		} else {
+			buf = seq.Render(buf)
+			if linkChanged {
+				buf = link.Render(buf)
 			}
-			if w.head.modes != w.upstream.modes {
-				seq = w.upstream.modes.Diff(w.head.modes).ToCommands(seq)
-				w.upstream.modes = w.head.modes
+			_, err := s.write(buf)
+			if err != nil {
+				return err
 			}
 		}
 	}
 
-	if len(seq) != 0 {
-		buf = seq.Render(buf)
-		_, err := w.target.Write(buf)
-		if err != nil {
-			return err
+	w.scratchCommands = seq[0:0]
+	w.scratchBytes = buf[0:0]
+
+	return nil
+}
+
+// ---
+
+// WriterState is a snapshot of Writer's complete SGR state including the stacks.
+type WriterState struct {
+	head  state
+	link  Hyperlink
+	stack stacks
+	marks []sinkMark
+}
+
+// ---
+
+type sink struct {
+	target    io.Writer
+	profile   ColorProfile
+	upstream  state
+	link      Hyperlink
+	buf       []byte
+	threshold int
+	lineFlush bool
+	writes    uint64
+}
+
+func (s *sink) buffered() bool {
+	return s.threshold != 0
+}
+
+func (s *sink) write(data []byte) (int, error) {
+	if !s.buffered() {
+		s.writes++
+		n, err := s.target.Write(data)
+		if err == nil && n != len(data) {
+			err = io.ErrShortWrite
 		}
+
+		return n, err
 	}
 
-	w.scratchCommands = seq[0:0]
-	w.scratchBytes = buf[0:0]
+	s.buf = append(s.buf, data...)
+
+	return len(data), s.autoFlush(bytes.IndexByte(data, '\n') >= 0)
+}
+
+func (s *sink) writeString(data string) (int, error) {
+	if !s.buffered() {
+		s.writes++
+		n, err := io.WriteString(s.target, data)
+		if err == nil && n != len(data) {
+			err = io.ErrShortWrite
+		}
+
+		return n, err
+	}
+
+	s.buf = append(s.buf, data...)
+
+	return len(data), s.autoFlush(strings.IndexByte(data, '\n') >= 0)
+}
+
+func (s *sink) autoFlush(newline bool) error {
+	if len(s.buf) >= s.threshold || (newline && s.lineFlush) {
+		return s.flush()
+	}
 
 	return nil
 }
 
+func (s *sink) flush() error {
+	if len(s.buf) == 0 {
+		return nil
+	}
+
+	s.writes++
+	n, err := s.target.Write(s.buf)
+	if err == nil && n != len(s.buf) {
+		err = io.ErrShortWrite
+	}
+	s.buf = s.buf[:copy(s.buf, s.buf[n:])]
+
+	return err
+}
+
+func (s *sink) mark() sinkMark {
+	return sinkMark{s.upstream, s.link, len(s.buf), s.writes}
+}
+
+func (s *sink) canRollback(m sinkMark) bool {
+	return s.writes == m.writes && len(s.buf) >= m.size
+}
+
+func (s *sink) rollback(m sinkMark) {
+	s.upstream = m.upstream
+	s.link = m.link
+	s.buf = s.buf[:m.size]
+}
+
+type sinkMark struct {
+	upstream state
+	link     Hyperlink
+	size     int
+	writes   uint64
+}
+
+// ---
+
+type stacks struct {
+	bgc   []Color
+	fgc   []Color
+	ulc   []Color
+	modes []ModeSet
+	links []Hyperlink
+}
+
+func (s stacks) clone() stacks {
+	return stacks{
+		bgc:   slices.Clone(s.bgc),
+		fgc:   slices.Clone(s.fgc),
+		ulc:   slices.Clone(s.ulc),
+		modes: slices.Clone(s.modes),
+		links: slices.Clone(s.links),
+	}
+}
+
+func (s *stacks) assign(other stacks) {
+	s.bgc = append(s.bgc[:0], other.bgc...)
+	s.fgc = append(s.fgc[:0], other.fgc...)
+	s.ulc = append(s.ulc[:0], other.ulc...)
+	s.modes = append(s.modes[:0], other.modes...)
+	s.links = append(s.links[:0], other.links...)
+}
+
 // ---
 
 type state struct {
@@ -55,6 +55,269 @@ func TestWriter(tt *testing.T) {
 		t.Expect(buf.String()).ToEqual("\x1b[34;58;5;1;3ma\x1b[0m")
 	})
 
+	t.Run("Hyperlink", func(t Test) {
+		const (
+			open  = "\x1b]8;;https://example.com\x1b\\"
+			close = "\x1b]8;;\x1b\\"
+		)
+
+		t.Run("Basic", func(t Test) {
+			buf := bytes.NewBuffer(nil)
+			writer := sgr.NewWriter(buf)
+			_, _ = writer.WriteString("a ")
+			writer.PushHyperlink(sgr.Hyperlink{URI: "https://example.com"})
+			writer.PushForegroundColor(sgr.Blue)
+			_, _ = writer.WriteString("b")
+			writer.PopForegroundColor()
+			_, _ = writer.WriteString("c")
+			writer.PopHyperlink()
+			_, _ = writer.WriteString(" d")
+			t.Expect(writer.Flush()).ToSucceed()
+			t.Expect(buf.String()).ToEqual("a \x1b[34m" + open + "b\x1b[0mc" + close + " d")
+		})
+
+		t.Run("Change", func(t Test) {
+			buf := bytes.NewBuffer(nil)
+			writer := sgr.NewWriter(buf)
+			writer.SetHyperlink(sgr.Hyperlink{URI: "https://example.com"})
+			_, _ = writer.WriteString("a")
+			writer.SetHyperlink(sgr.Hyperlink{URI: "https://example.com"})
+			_, _ = writer.WriteString("b")
+			writer.SetHyperlink(sgr.Hyperlink{URI: "https://example.org", ID: "x"})
+			_, _ = writer.WriteString("c")
+			writer.SetHyperlink(sgr.Hyperlink{})
+			writer.SetHyperlink(sgr.Hyperlink{URI: "https://example.org", ID: "x"})
+			_, _ = writer.WriteString("d")
+			t.Expect(writer.Flush()).ToSucceed()
+			t.Expect(buf.String()).ToEqual(open + "ab\x1b]8;id=x;https://example.org\x1b\\cd")
+		})
+
+		t.Run("Reset", func(t Test) {
+			buf := bytes.NewBuffer(nil)
+			writer := sgr.NewWriter(buf)
+			writer.SetModes(sgr.Bold.ModeSet(), sgr.ModeAdd)
+			writer.SetHyperlink(sgr.Hyperlink{URI: "https://example.com"})
+			_, _ = writer.WriteString("a")
+			writer.Reset()
+			t.Expect(writer.Flush()).ToSucceed()
+			t.Expect(buf.String()).ToEqual("\x1b[1m" + open + "a\x1b[0m" + close)
+		})
+
+		t.Run("ColorProfile", func(t Test) {
+			plain := bytes.NewBuffer(nil)
+			colored := bytes.NewBuffer(nil)
+			writer := sgr.NewTeeWriter([]sgr.Target{
+				{Writer: plain, Profile: sgr.ColorProfileNone},
+				{Writer: colored, Profile: sgr.ColorProfile16},
+			})
+			writer.PushHyperlink(sgr.Hyperlink{URI: "https://example.com"})
+			_, _ = writer.WriteString("a")
+			writer.PopHyperlink()
+			t.Expect(writer.Flush()).ToSucceed()
+			t.Expect(plain.String()).ToEqual("a")
+			t.Expect(colored.String()).ToEqual(open + "a" + close)
+		})
+
+		t.Run("Rollback", func(t Test) {
+			buf := bytes.NewBuffer(nil)
+			writer := sgr.NewWriter(buf, sgr.WithBuffer(1024))
+			_, _ = writer.WriteString("a")
+			snapshot := writer.Snapshot()
+			writer.PushHyperlink(sgr.Hyperlink{URI: "https://example.com"})
+			_, _ = writer.WriteString("b")
+			t.Expect(writer.Rollback(snapshot)).ToBeTrue()
+			_, _ = writer.WriteString("c")
+			writer.SetHyperlink(sgr.Hyperlink{URI: "https://example.com"})
+			writer.Restore(snapshot)
+			_, _ = writer.WriteString("d")
+			t.Expect(writer.Flush()).ToSucceed()
+			t.Expect(buf.String()).ToEqual("acd")
+		})
+	})
+
+	t.Run("Optimization", func(t Test) {
+		run := func(optimization sgr.Optimization) string {
+			buf := bytes.NewBuffer(nil)
+			writer := sgr.NewWriter(buf, sgr.WithOptimization(optimization))
+			writer.SetBackgroundColor(sgr.Blue)
+			writer.SetForegroundColor(sgr.Red)
+			writer.SetModes(sgr.ModeSetWith(sgr.Bold, sgr.Italic, sgr.Underlined), sgr.ModeReplace)
+			t.Expect(writer.Write([]byte("a"))).ToSucceed()
+			writer.SetBackgroundColor(sgr.Default)
+			writer.SetForegroundColor(sgr.Green)
+			writer.SetModes(sgr.EmptyModeSet(), sgr.ModeReplace)
+			t.Expect(writer.Write([]byte("b"))).ToSucceed()
+			writer.SetModes(sgr.Bold.ModeSet(), sgr.ModeAdd)
+			t.Expect(writer.Write([]byte("c"))).ToSucceed()
+			writer.Reset()
+			t.Expect(writer.Flush()).ToSucceed()
+
+			return buf.String()
+		}
+
+		t.Run("Incremental", func(t Test) {
+			t.Expect(run(sgr.OptimizeIncremental)).ToEqual("\x1b[44;31;3;1;4ma\x1b[49;32;23;22;24mb\x1b[1mc\x1b[0m")
+		})
+
+		t.Run("Size", func(t Test) {
+			t.Expect(run(sgr.OptimizeSize)).ToEqual("\x1b[44;31;3;1;4ma\x1b[0;32mb\x1b[1mc\x1b[0m")
+		})
+	})
+
+	t.Run("Snapshot", func(t Test) {
+		buf := bytes.NewBuffer(nil)
+		writer := sgr.NewWriter(buf)
+		writer.PushForegroundColor(sgr.Blue)
+		writer.PushModes(sgr.Bold.ModeSet(), sgr.ModeAdd)
+		snapshot := writer.Snapshot()
+
+		writer.PopModes()
+		writer.PushBackgroundColor(sgr.Red)
+		writer.PushForegroundColor(sgr.Green)
+		t.Expect(writer.Write([]byte("a"))).ToSucceed()
+
+		writer.Restore(snapshot)
+		t.Expect(writer.Write([]byte("b"))).ToSucceed()
+		writer.PopModes()
+		writer.PopForegroundColor()
+		t.Expect(writer.Write([]byte("c"))).ToSucceed()
+
+		writer.Restore(snapshot)
+		t.Expect(writer.Write([]byte("d"))).ToSucceed()
+		writer.Reset()
+		t.Expect(writer.Flush()).ToSucceed()
+
+		t.Expect(buf.String()).ToEqual("\x1b[41;32ma\x1b[49;34;1mb\x1b[0mc\x1b[34;1md\x1b[0m")
+	})
+
+	t.Run("Tee", func(t Test) {
+		bufs := [4]bytes.Buffer{}
+		writer := sgr.NewTeeWriter([]sgr.Target{
+			{Writer: &bufs[0], Profile: sgr.ColorProfileTrueColor},
+			{Writer: &bufs[1], Profile: sgr.ColorProfile256},
+			{Writer: &bufs[2], Profile: sgr.ColorProfile16},
+			{Writer: &bufs[3], Profile: sgr.ColorProfileNone},
+		})
+
+		writer.PushForegroundColor(sgr.RGB(250, 0, 0))
+		writer.PushModes(sgr.Bold.ModeSet(), sgr.ModeAdd)
+		t.Expect(writer.Write([]byte("a"))).ToSucceed().AndResult().ToEqual(1)
+		writer.SetForegroundColor(sgr.RGB(255, 10, 0))
+		t.Expect(writer.WriteString("b")).ToSucceed().AndResult().ToEqual(1)
+		writer.PopModes()
+		writer.PopForegroundColor()
+		t.Expect(writer.Write([]byte("c"))).ToSucceed().AndResult().ToEqual(1)
+
+		t.Expect(bufs[0].String()).ToEqual("\x1b[38;2;250;0;0;1ma\x1b[38;2;255;10;0mb\x1b[0mc")
+		t.Expect(bufs[1].String()).ToEqual("\x1b[38;5;196;1mab\x1b[0mc")
+		t.Expect(bufs[2].String()).ToEqual("\x1b[91;1mab\x1b[0mc")
+		t.Expect(bufs[3].String()).ToEqual("abc")
+
+		t.Run("Error", func(t Test) {
+			writer := sgr.NewTeeWriter([]sgr.Target{{Writer: io.Discard}, {Writer: failingWriter{}}})
+			t.Expect(writer.Write([]byte("a"))).ToFailWith(errFailingWriterError)
+			t.Expect(writer.WriteString("a")).ToFailWith(errFailingWriterError)
+			writer.SetModes(sgr.Bold.ModeSet(), sgr.ModeAdd)
+			t.Expect(writer.Write([]byte("a"))).ToFailWith(errFailingWriterError)
+			t.Expect(writer.WriteString("a")).ToFailWith(errFailingWriterError)
+		})
+
+		t.Run("ShortWrite", func(t Test) {
+			writer := sgr.NewTeeWriter([]sgr.Target{{Writer: shortWriter{}}})
+			t.Expect(writer.Write([]byte("ab"))).ToFailWith(io.ErrShortWrite)
+			t.Expect(writer.WriteString("ab")).ToFailWith(io.ErrShortWrite)
+		})
+	})
+
+	t.Run("ColorProfile", func(t Test) {
+		buf := bytes.NewBuffer(nil)
+		writer := sgr.NewWriter(buf, sgr.WithColorProfile(sgr.ColorProfile16))
+		writer.SetBackgroundColor(sgr.RGB(10, 10, 30))
+		writer.SetForegroundColor(sgr.PaletteColor(10))
+		t.Expect(writer.Write([]byte("a"))).ToSucceed()
+		t.Expect(buf.String()).ToEqual("\x1b[40;92ma")
+
+		writer.SetUnderlineColor(sgr.Red)
+		writer.SetModes(sgr.Underlined.ModeSet(), sgr.ModeAdd)
+		t.Expect(writer.Write([]byte("b"))).ToSucceed()
+		t.Expect(writer.Write([]byte("c"))).ToSucceed()
+		t.Expect(buf.String()).ToEqual("\x1b[40;92ma\x1b[4mbc")
+	})
+
+	t.Run("Buffer", func(t Test) {
+		t.Run("Threshold", func(t Test) {
+			buf := &countingWriter{bytes.NewBuffer(nil), 0}
+			writer := sgr.NewWriter(buf, sgr.WithBuffer(8))
+			writer.SetForegroundColor(sgr.Red)
+			t.Expect(writer.Write([]byte("ab"))).ToSucceed().AndResult().ToEqual(2)
+			t.Expect(buf.writes).ToEqual(0)
+			writer.SetForegroundColor(sgr.Green)
+			t.Expect(writer.WriteString("c\n")).ToSucceed().AndResult().ToEqual(2)
+			t.Expect(buf.writes).ToEqual(1)
+			t.Expect(buf.String()).ToEqual("\x1b[31mab\x1b[32mc\n")
+			writer.Reset()
+			t.Expect(writer.Write([]byte("d"))).ToSucceed()
+			t.Expect(buf.writes).ToEqual(1)
+			t.Expect(writer.Flush()).ToSucceed()
+			t.Expect(writer.Flush()).ToSucceed()
+			t.Expect(buf.writes).ToEqual(2)
+			t.Expect(buf.String()).ToEqual("\x1b[31mab\x1b[32mc\n\x1b[0md")
+		})
+
+		t.Run("LineFlush", func(t Test) {
+			buf := &countingWriter{bytes.NewBuffer(nil), 0}
+			writer := sgr.NewWriter(buf, sgr.WithBuffer(1024), sgr.WithLineFlush())
+			writer.SetForegroundColor(sgr.Red)
+			t.Expect(writer.Write([]byte("ab"))).ToSucceed()
+			t.Expect(writer.WriteString("c")).ToSucceed()
+			t.Expect(buf.writes).ToEqual(0)
+			t.Expect(writer.Write([]byte("\n"))).ToSucceed()
+			t.Expect(buf.writes).ToEqual(1)
+			t.Expect(writer.WriteString("d\n")).ToSucceed()
+			t.Expect(buf.writes).ToEqual(2)
+			t.Expect(buf.String()).ToEqual("\x1b[31mabc\nd\n")
+		})
+
+		t.Run("Rollback", func(t Test) {
+			buf := bytes.NewBuffer(nil)
+			writer := sgr.NewWriter(buf, sgr.WithBuffer(1024))
+			writer.SetForegroundColor(sgr.Red)
+			t.Expect(writer.Write([]byte("a"))).ToSucceed()
+			snapshot := writer.Snapshot()
+			writer.PushModes(sgr.Bold.ModeSet(), sgr.ModeAdd)
+			writer.PushForegroundColor(sgr.Blue)
+			t.Expect(writer.Write([]byte("bbbbbbbbbbbbbbbbbbbb"))).ToSucceed()
+			t.Expect(writer.Rollback(snapshot)).ToBeTrue()
+			t.Expect(writer.Write([]byte("c"))).ToSucceed()
+			t.Expect(writer.Flush()).ToSucceed()
+			t.Expect(writer.Rollback(snapshot)).ToBeFalse()
+			t.Expect(buf.String()).ToEqual("\x1b[31mac")
+		})
+
+		t.Run("RollbackUnbuffered", func(t Test) {
+			buf := bytes.NewBuffer(nil)
+			writer := sgr.NewWriter(buf)
+			snapshot := writer.Snapshot()
+			t.Expect(writer.Rollback(snapshot)).ToBeTrue()
+			t.Expect(writer.Write([]byte("a"))).ToSucceed()
+			t.Expect(writer.Rollback(snapshot)).ToBeFalse()
+			t.Expect(sgr.NewWriter(buf).Rollback(snapshot)).ToBeTrue()
+			t.Expect(sgr.NewTeeWriter(nil).Rollback(snapshot)).ToBeFalse()
+		})
+
+		t.Run("Error", func(t Test) {
+			writer := sgr.NewWriter(failingWriter{}, sgr.WithBuffer(4))
+			t.Expect(writer.Write([]byte("ab"))).ToSucceed()
+			t.Expect(writer.Write([]byte("cd"))).ToFailWith(errFailingWriterError)
+			t.Expect(writer.WriteString("ef")).ToFailWith(errFailingWriterError)
+			t.Expect(writer.Flush()).ToFailWith(errFailingWriterError)
+
+			writer = sgr.NewWriter(shortWriter{}, sgr.WithBuffer(4))
+			t.Expect(writer.Write([]byte("abcd"))).ToFailWith(io.ErrShortWrite)
+			t.Expect(writer.Flush()).ToFailWith(io.ErrShortWrite)
+		})
+	})
+
 	t.Run("Error", func(t Test) {
 		writer := sgr.NewWriter(failingWriter{})
 		writer.SetForegroundColor(sgr.Blue)
@@ -81,19 +344,39 @@ func BenchmarkWriter(b *testing.B) {
 		},
 	}
 
-	buf := bytes.NewBuffer(make([]byte, 0, 2048))
-	r := newRenderer(&rec, buf)
+	run := func(b *testing.B, options ...sgr.WriterOption) {
+		buf := &countingWriter{bytes.NewBuffer(make([]byte, 0, 2048)), 0}
+		r := newRenderer(&rec, buf, options...)
 
-	b.ResetTimer()
-	b.ReportAllocs()
+		b.ResetTimer()
+		b.ReportAllocs()
 
-	for i := 0; i != b.N; i++ {
-		buf.Reset()
-		err := r.run()
-		if err != nil {
-			b.Fatal(err)
+		for i := 0; i != b.N; i++ {
+			buf.buf.Reset()
+			err := r.run()
+			if err != nil {
+				b.Fatal(err)
+			}
+			err = r.w.Flush()
+			if err != nil {
+				b.Fatal(err)
+			}
 		}
+
+		b.ReportMetric(float64(buf.writes)/float64(b.N), "writes/op")
 	}
+
+	b.Run("Unbuffered", func(b *testing.B) {
+		run(b)
+	})
+
+	b.Run("Buffered", func(b *testing.B) {
+		run(b, sgr.WithBuffer(4096))
+	})
+
+	b.Run("LineBuffered", func(b *testing.B) {
+		run(b, sgr.WithBuffer(4096), sgr.WithLineFlush())
+	})
 }
 
 // ---
@@ -112,10 +395,10 @@ func (r *record) Write(target io.Writer) error {
 
 // ---
 
-func newRenderer(r *record, target io.Writer) *renderer {
+func newRenderer(r *record, target io.Writer, options ...sgr.WriterOption) *renderer {
 	return &renderer{
 		r,
-		sgr.NewWriter(target),
+		sgr.NewWriter(target, options...),
 		make([]byte, 0, 36),
 	}
 }
@@ -390,6 +673,33 @@ func (failingWriter) Write([]byte) (int, error) {
 
 // ---
 
+// countingWriter counts Write calls.
+// The buffer is not embedded so that no other write methods like WriteString bypass the counter.
+type countingWriter struct {
+	buf    *bytes.Buffer
+	writes int
+}
+
+func (w *countingWriter) Write(data []byte) (int, error) {
+	w.writes++
+
+	return w.buf.Write(data)
+}
+
+func (w *countingWriter) String() string {
+	return w.buf.String()
+}
+
+// ---
+
+type shortWriter struct{}
+
+func (shortWriter) Write(data []byte) (int, error) {
+	return len(data) / 2, nil
+}
+
+// ---
+
 var errFailingWriterError = errors.New("test writer error")
 
 // ---
@@ -0,0 +1,171 @@
+// Package sgrhtml provides facilities for converting text containing CSI/SGR ANSI Escape Sequences to HTML.
+//
+// Styled pieces of text are wrapped into flat, never nested span elements, each describing the complete style
+// of the text it contains. Text having terminal default style is not wrapped at all.
+// The output is intended to be placed inside a pre element or any other element preserving white space.
+// Escape sequences other than SGR are removed from the output.
+package sgrhtml
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/pamburus/go-ansi-esc/sgr"
+)
+
+// ---
+
+// Convert converts data containing CSI/SGR sequences to HTML.
+func Convert(data []byte, options ...Option) []byte {
+	buf := bytes.NewBuffer(make([]byte, 0, len(data)*2))
+	w := NewWriter(buf, options...)
+	_, _ = w.Write(data)
+	_ = w.Close()
+
+	return buf.Bytes()
+}
+
+// ---
+
+// NewWriter constructs a new Writer that converts all data written to it to HTML
+// and writes the result to the target writer.
+func NewWriter(target io.Writer, options ...Option) *Writer {
+	o := newOptions(options)
+
+	return &Writer{
+		target: target,
+		r:      renderer{o: o},
+	}
+}
+
+// Writer is an io.WriteCloser that interprets CSI/SGR sequences in a stream written to it
+// and converts the stream to HTML.
+// Close must be called at the end of the stream to close an open span element if any.
+type Writer struct {
+	target  io.Writer
+	r       renderer
+	interp  sgr.Interpreter
+	pending []byte
+	attrs   []byte
+	scratch []byte
+	buf     []byte
+	open    bool
+}
+
+// Write converts data to HTML and writes the result to the target writer.
+// Incomplete escape sequences and UTF-8 encoded characters at the end of data are kept until the next Write or Close call.
+func (w *Writer) Write(data []byte) (int, error) {
+	w.pending = append(w.pending, data...)
+
+	err := w.process(false)
+	if err != nil {
+		return 0, err
+	}
+
+	return len(data), nil
+}
+
+// Close converts all remaining data, closes an open span element if any and writes the result to the target writer.
+// It does not close the target writer.
+func (w *Writer) Close() error {
+	err := w.process(true)
+	if err != nil {
+		return err
+	}
+
+	if w.open {
+		w.open = false
+		w.attrs = w.attrs[:0]
+
+		_, err = w.target.Write([]byte(spanEnd))
+	}
+
+	return err
+}
+
+func (w *Writer) process(atEOF bool) error {
+	buf := w.buf[:0]
+	data := w.pending
+
+	for {
+		n, token, _ := sgr.ScanTokens(data, atEOF)
+		if n == 0 {
+			break
+		}
+		data = data[n:]
+
+		if sgr.IsEscape(token) {
+			if sgr.IsSequence(token) {
+				seq, _ := sgr.ParseSequence(token)
+				w.interp.Apply(seq)
+			}
+
+			continue
+		}
+
+		buf = w.text(buf, token)
+	}
+
+	w.pending = w.pending[:copy(w.pending, data)]
+	w.buf = buf[:0]
+
+	if len(buf) == 0 {
+		return nil
+	}
+
+	_, err := w.target.Write(buf)
+
+	return err
+}
+
+func (w *Writer) text(buf, text []byte) []byte {
+	w.scratch = w.r.attributes(w.scratch[:0], w.interp.Style())
+
+	if !w.open || !bytes.Equal(w.scratch, w.attrs) {
+		if w.open {
+			buf = append(buf, spanEnd...)
+			w.open = false
+		}
+
+		if len(w.scratch) != 0 {
+			buf = append(buf, spanBegin...)
+			buf = append(buf, w.scratch...)
+			buf = append(buf, '>')
+			w.open = true
+		}
+
+		w.attrs, w.scratch = w.scratch, w.attrs
+	}
+
+	return appendEscaped(buf, text)
+}
+
+// ---
+
+func appendEscaped(buf, text []byte) []byte {
+	for _, c := range text {
+		switch c {
+		case '&':
+			buf = append(buf, "&amp;"...)
+		case '<':
+			buf = append(buf, "&lt;"...)
+		case '>':
+			buf = append(buf, "&gt;"...)
+		case '"':
+			buf = append(buf, "&#34;"...)
+		case '\'':
+			buf = append(buf, "&#39;"...)
+		default:
+			buf = append(buf, c)
+		}
+	}
+
+	return buf
+}
+
+// ---
+
+const (
+	spanBegin = "<span"
+	spanEnd   = "</span>"
+)
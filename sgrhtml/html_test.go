@@ -0,0 +1,159 @@
+package sgrhtml_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	. "github.com/pamburus/go-tst/tst"
+
+	"github.com/pamburus/go-ansi-esc/sgr"
+	"github.com/pamburus/go-ansi-esc/sgrhtml"
+)
+
+func TestConvert(tt *testing.T) {
+	t := New(tt)
+
+	convert := func(input string, options ...sgrhtml.Option) string {
+		return string(sgrhtml.Convert([]byte(input), options...))
+	}
+
+	t.Run("Plain", func(t Test) {
+		t.Expect(convert("a < b & \"c\" > 'd'")).ToEqual("a &lt; b &amp; &#34;c&#34; &gt; &#39;d&#39;")
+	})
+
+	t.Run("InlineStyles", func(t Test) {
+		t.Run("Colors", func(t Test) {
+			t.Expect(
+				convert("a\x1b[31mb\x1b[1mc\x1b[22;48;2;1;2;3md\x1b[mе\x1b[2Kf"),
+			).ToEqual(
+				`a<span style="color:#cd0000">b</span><span style="color:#cd0000;font-weight:bold">c</span>` +
+					`<span style="color:#cd0000;background-color:#010203">d</span>еf`,
+			)
+		})
+
+		t.Run("Merge", func(t Test) {
+			t.Expect(convert("\x1b[31ma\x1b[31mb\x1b[5mc\x1b[0m")).ToEqual(`<span style="color:#cd0000">abc</span>`)
+		})
+
+		t.Run("Reversed", func(t Test) {
+			t.Expect(convert("\x1b[7ma\x1b[32mb\x1b[m")).ToEqual(
+				`<span style="color:#000000;background-color:#e5e5e5">a</span>` +
+					`<span style="color:#000000;background-color:#00cd00">b</span>`,
+			)
+		})
+
+		t.Run("Faint", func(t Test) {
+			t.Expect(convert("\x1b[2;38;5;231;48;5;16ma", sgrhtml.WithDefaultColors(sgr.RGB(255, 255, 255), sgr.RGB(0, 0, 0)))).ToEqual(
+				`<span style="color:#7f7f7f;background-color:#000000">a</span>`,
+			)
+		})
+
+		t.Run("Concealed", func(t Test) {
+			t.Expect(convert("\x1b[8;31ma")).ToEqual(`<span style="color:transparent">a</span>`)
+		})
+
+		t.Run("Decorations", func(t Test) {
+			t.Expect(convert("\x1b[4;58;5;1ma\x1b[21;53;9mb\x1b[24mc")).ToEqual(
+				`<span style="text-decoration-color:#cd0000;text-decoration-line:underline">a</span>` +
+					`<span style="text-decoration-color:#cd0000;text-decoration-line:underline overline line-through;text-decoration-style:double">b</span>` +
+					`<span style="text-decoration-line:overline line-through">c</span>`,
+			)
+			t.Expect(convert("\x1b[4:3;58;5;1ma\x1b[4:4mb\x1b[4:5mc\x1b[4md")).ToEqual(
+				`<span style="text-decoration-color:#cd0000;text-decoration-line:underline;text-decoration-style:wavy">a</span>` +
+					`<span style="text-decoration-color:#cd0000;text-decoration-line:underline;text-decoration-style:dotted">b</span>` +
+					`<span style="text-decoration-color:#cd0000;text-decoration-line:underline;text-decoration-style:dashed">c</span>` +
+					`<span style="text-decoration-color:#cd0000;text-decoration-line:underline">d</span>`,
+			)
+		})
+
+		t.Run("Modes", func(t Test) {
+			t.Expect(convert("\x1b[3;51;73ma\x1b[0;52;74mb")).ToEqual(
+				`<span style="font-style:italic;outline:1px solid;vertical-align:super;font-size:smaller">a</span>` +
+					`<span style="border:1px solid;border-radius:0.5em;vertical-align:sub;font-size:smaller">b</span>`,
+			)
+		})
+	})
+
+	t.Run("Classes", func(t Test) {
+		options := []sgrhtml.Option{sgrhtml.WithOutputMode(sgrhtml.Classes), sgrhtml.WithClassPrefix("t-")}
+
+		t.Expect(
+			convert("\x1b[91;44;1;4;58;5;3ma\x1b[38;2;1;2;3;7;21;53mb\x1b[0;7;5mc\x1b[0;8;9;2md", options...),
+		).ToEqual(
+			`<span class="t-fg-9 t-bg-4 t-ul-3 t-underline t-bold">a</span>` +
+				`<span class="t-fg-4 t-ul-3 t-double-underline t-overline t-bold" style="background-color:#010203">b</span>` +
+				`<span class="t-inverse-fg t-inverse-bg t-blink">c</span>` +
+				`<span class="t-concealed t-crossed-out">d</span>`,
+		)
+
+		t.Expect(
+			convert("\x1b[2ma\x1b[31mb\x1b[7mc\x1b[27;38;2;1;2;3;48;2;255;255;255md\x1b[0;4:3;58;5;1me", options...),
+		).ToEqual(
+			`<span class="t-faint">a</span>` +
+				`<span class="t-faint t-fg-1">b</span>` +
+				`<span class="t-faint t-inverse-fg t-bg-1">c</span>` +
+				`<span style="color:#808081;background-color:#ffffff">d</span>` +
+				`<span class="t-ul-1 t-curly-underline">e</span>`,
+		)
+	})
+
+	t.Run("Stylesheet", func(t Test) {
+		css := sgrhtml.Stylesheet(sgrhtml.WithClassPrefix("t-"))
+		for _, rule := range []string{
+			".t-bold{font-weight:bold}\n",
+			".t-fg-9{color:#ff0000}\n",
+			".t-bg-232{background-color:#080808}\n",
+			".t-ul-1{text-decoration-color:#cd0000}\n",
+			".t-inverse-fg{color:#000000}\n",
+			".t-inverse-bg{background-color:#e5e5e5}\n",
+			".t-blink{animation:t-blink 1s step-end infinite}\n",
+			"@keyframes t-blink{50%{opacity:0}}\n",
+			".t-double-underline.t-overline.t-crossed-out{text-decoration-line:underline overline line-through;text-decoration-style:double}\n",
+			".t-crossed-out{text-decoration-line:line-through}\n",
+			".t-curly-underline{text-decoration-line:underline;text-decoration-style:wavy}\n",
+			".t-dotted-underline.t-overline{text-decoration-line:underline overline;text-decoration-style:dotted}\n",
+			".t-dashed-underline{text-decoration-line:underline;text-decoration-style:dashed}\n",
+			".t-faint{color:#727272}\n",
+			".t-faint.t-inverse-fg{color:#727272}\n",
+			".t-faint.t-fg-9{color:#7f0000}\n",
+		} {
+			t.Expect(strings.Contains(css, rule)).ToBeTrue()
+		}
+	})
+}
+
+func TestWriter(tt *testing.T) {
+	t := New(tt)
+
+	t.Run("Stream", func(t Test) {
+		buf := bytes.NewBuffer(nil)
+		w := sgrhtml.NewWriter(buf)
+		for _, chunk := range []string{"a\x1b[", "31mb\xc3", "\xa9\x1b[0", "mc\x1b[1m", "d"} {
+			t.Expect(w.Write([]byte(chunk))).ToSucceed().AndResult().ToEqual(len(chunk))
+		}
+		t.Expect(w.Close()).ToSucceed()
+		t.Expect(buf.String()).ToEqual(`a<span style="color:#cd0000">bé</span>c<span style="font-weight:bold">d</span>`)
+	})
+
+	t.Run("Error", func(t Test) {
+		w := sgrhtml.NewWriter(failingWriter{})
+		t.Expect(w.Write([]byte("\x1b[1m"))).ToSucceed()
+		t.Expect(w.Write([]byte("a"))).ToFailWith(errFailingWriterError)
+
+		w = sgrhtml.NewWriter(failingWriter{})
+		t.Expect(w.Write([]byte("\x1b[1m\xc3"))).ToSucceed()
+		t.Expect(w.Close()).ToFailWith(errFailingWriterError)
+	})
+}
+
+// ---
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errFailingWriterError
+}
+
+var errFailingWriterError = errors.New("test writer error")
@@ -0,0 +1,83 @@
+package sgrhtml
+
+import "github.com/pamburus/go-ansi-esc/sgr"
+
+// ---
+
+// Complete set of valid OutputMode values.
+const (
+	// InlineStyles makes converter describe styles using style attributes of span elements.
+	InlineStyles OutputMode = iota
+	// Classes makes converter describe styles using class attributes of span elements
+	// referring classes defined in a stylesheet that can be generated using Stylesheet function.
+	// Colors that cannot be represented by a predefined class, like RGB colors, are still described using style attributes.
+	Classes
+)
+
+// OutputMode defines how styles are represented in HTML output.
+type OutputMode uint8
+
+// ---
+
+// Option is an option that can be passed to NewWriter, Convert or Stylesheet to customize the output.
+type Option func(*options)
+
+// WithOutputMode returns an Option that sets the output mode.
+// Default is InlineStyles.
+func WithOutputMode(mode OutputMode) Option {
+	return func(o *options) {
+		o.mode = mode
+	}
+}
+
+// WithPalette returns an Option that sets the palette used to resolve BasicColor and PaletteColor values.
+// Default is sgr.DefaultPalette.
+func WithPalette(palette sgr.Palette) Option {
+	return func(o *options) {
+		o.palette = palette
+	}
+}
+
+// WithDefaultColors returns an Option that sets the colors used in place of terminal default
+// foreground and background colors in cases they need to be rendered explicitly, like for Reversed or Faint modes.
+// Default is light gray text on black background.
+func WithDefaultColors(foreground, background sgr.RGBColor) Option {
+	return func(o *options) {
+		o.foreground = foreground
+		o.background = background
+	}
+}
+
+// WithClassPrefix returns an Option that sets the prefix for all class names used in Classes output mode.
+// Default is "sgr-".
+func WithClassPrefix(prefix string) Option {
+	return func(o *options) {
+		o.prefix = prefix
+	}
+}
+
+// ---
+
+type options struct {
+	mode       OutputMode
+	palette    sgr.Palette
+	foreground sgr.RGBColor
+	background sgr.RGBColor
+	prefix     string
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{
+		mode:       InlineStyles,
+		palette:    sgr.DefaultPalette,
+		foreground: sgr.RGB(0xe5, 0xe5, 0xe5),
+		background: sgr.RGB(0, 0, 0),
+		prefix:     "sgr-",
+	}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o
+}
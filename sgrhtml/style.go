@@ -0,0 +1,254 @@
+package sgrhtml
+
+import (
+	"strconv"
+
+	"github.com/pamburus/go-ansi-esc/sgr"
+)
+
+// ---
+
+// renderer renders style and class attributes of span elements for the given styles.
+type renderer struct {
+	o       *options
+	classes []byte
+	styles  []byte
+}
+
+// attributes appends attributes describing the given style to buf and returns modified buf.
+func (r *renderer) attributes(buf []byte, s sgr.Style) []byte {
+	r.classes = r.classes[:0]
+	r.styles = r.styles[:0]
+
+	r.colors(s)
+	r.decorations(s)
+
+	for _, mode := range simpleModes {
+		if s.Modes.Has(mode.mode) {
+			if r.o.mode == Classes {
+				r.class(mode.class)
+			} else if mode.style != "" {
+				r.styles = append(r.styles, mode.style...)
+				r.styles = append(r.styles, ';')
+			}
+		}
+	}
+
+	if len(r.classes) != 0 {
+		buf = append(buf, ` class="`...)
+		buf = append(buf, r.classes...)
+		buf = append(buf, '"')
+	}
+
+	if len(r.styles) != 0 {
+		buf = append(buf, ` style="`...)
+		buf = append(buf, r.styles[:len(r.styles)-1]...)
+		buf = append(buf, '"')
+	}
+
+	return buf
+}
+
+func (r *renderer) colors(s sgr.Style) {
+	fg, bg := s.Foreground, s.Background
+	fgDefault, bgDefault := r.o.foreground, r.o.background
+	reversed := s.Modes.Has(sgr.Reversed)
+	if reversed {
+		fg, bg = bg, fg
+		fgDefault, bgDefault = bgDefault, fgDefault
+	}
+
+	switch {
+	case s.Modes.Has(sgr.Concealed):
+		if r.o.mode == Classes {
+			r.class("concealed")
+		} else {
+			r.style("color", "transparent")
+		}
+	case s.Modes.Has(sgr.Faint) && r.o.mode == Classes && !fg.IsRGBColor():
+		r.class("faint")
+		r.color("fg", "color", fg, fgDefault, reversed)
+	case s.Modes.Has(sgr.Faint):
+		r.styleColor("color", blend(r.o.resolve(fg, fgDefault), r.o.resolve(bg, bgDefault)))
+	default:
+		r.color("fg", "color", fg, fgDefault, reversed)
+	}
+
+	r.color("bg", "background-color", bg, bgDefault, reversed)
+
+	if underlineKind(s.Modes) != nil {
+		r.color("ul", "text-decoration-color", s.UnderlineColor, 0, false)
+	}
+}
+
+func (r *renderer) color(class, property string, c sgr.Color, def sgr.RGBColor, reversed bool) {
+	switch {
+	case c.IsDefaultColor() || c.IsZero():
+		if reversed {
+			if r.o.mode == Classes {
+				r.class("inverse-" + class)
+			} else {
+				r.styleColor(property, def)
+			}
+		}
+	case r.o.mode == Classes && !c.IsRGBColor():
+		r.classIndex(class, uint8(c.AsPaletteColor()))
+	default:
+		rgb, _ := r.o.palette.Resolve(c)
+		r.styleColor(property, rgb)
+	}
+}
+
+func (r *renderer) decorations(s sgr.Style) {
+	kind := underlineKind(s.Modes)
+	underline := kind != nil
+	overline := s.Modes.Has(sgr.Overlined)
+	crossedOut := s.Modes.Has(sgr.CrossedOut)
+
+	if r.o.mode == Classes {
+		if underline {
+			r.class(kind.class)
+		}
+		if overline {
+			r.class("overline")
+		}
+		if crossedOut {
+			r.class("crossed-out")
+		}
+
+		return
+	}
+
+	if underline || overline || crossedOut {
+		r.styles = append(r.styles, "text-decoration-line:"...)
+		r.styles = appendDecorationLines(r.styles, underline, overline, crossedOut)
+		r.styles = append(r.styles, ';')
+	}
+
+	if underline && kind.style != "" {
+		r.style("text-decoration-style", kind.style)
+	}
+}
+
+func (r *renderer) class(name string) {
+	if len(r.classes) != 0 {
+		r.classes = append(r.classes, ' ')
+	}
+	r.classes = append(r.classes, r.o.prefix...)
+	r.classes = append(r.classes, name...)
+}
+
+func (r *renderer) classIndex(name string, index uint8) {
+	r.class(name)
+	r.classes = append(r.classes, '-')
+	r.classes = strconv.AppendUint(r.classes, uint64(index), 10)
+}
+
+func (r *renderer) style(property, value string) {
+	r.styles = append(r.styles, property...)
+	r.styles = append(r.styles, ':')
+	r.styles = append(r.styles, value...)
+	r.styles = append(r.styles, ';')
+}
+
+func (r *renderer) styleColor(property string, color sgr.RGBColor) {
+	r.styles = append(r.styles, property...)
+	r.styles = append(r.styles, ':')
+	r.styles = appendColor(r.styles, color)
+	r.styles = append(r.styles, ';')
+}
+
+// ---
+
+func (o *options) resolve(c sgr.Color, def sgr.RGBColor) sgr.RGBColor {
+	if rgb, ok := o.palette.Resolve(c); ok {
+		return rgb
+	}
+
+	return def
+}
+
+// ---
+
+func blend(a, b sgr.RGBColor) sgr.RGBColor {
+	mix := func(x, y uint8) uint8 {
+		return uint8((uint(x) + uint(y)) / 2)
+	}
+
+	return sgr.RGB(mix(a.R(), b.R()), mix(a.G(), b.G()), mix(a.B(), b.B()))
+}
+
+func appendColor(buf []byte, color sgr.RGBColor) []byte {
+	const digits = "0123456789abcdef"
+
+	return append(buf, '#',
+		digits[color.R()>>4], digits[color.R()&0xF],
+		digits[color.G()>>4], digits[color.G()&0xF],
+		digits[color.B()>>4], digits[color.B()&0xF],
+	)
+}
+
+func appendDecorationLines(buf []byte, underline, overline, crossedOut bool) []byte {
+	n := len(buf)
+	add := func(line string) {
+		if len(buf) != n {
+			buf = append(buf, ' ')
+		}
+		buf = append(buf, line...)
+	}
+
+	if underline {
+		add("underline")
+	}
+	if overline {
+		add("overline")
+	}
+	if crossedOut {
+		add("line-through")
+	}
+
+	return buf
+}
+
+// underlineKind returns the kind of underline for the given modes or nil if there is no underline.
+// If several underline modes are set, the most distinctive one is used.
+func underlineKind(modes sgr.ModeSet) *underline {
+	for i := range underlineKinds {
+		if modes.Has(underlineKinds[i].mode) {
+			return &underlineKinds[i]
+		}
+	}
+
+	return nil
+}
+
+// ---
+
+type underline struct {
+	mode  sgr.Mode
+	class string
+	style string
+}
+
+var underlineKinds = []underline{
+	{sgr.CurlyUnderlined, "curly-underline", "wavy"},
+	{sgr.DottedUnderlined, "dotted-underline", "dotted"},
+	{sgr.DashedUnderlined, "dashed-underline", "dashed"},
+	{sgr.DoublyUnderlined, "double-underline", "double"},
+	{sgr.Underlined, "underline", ""},
+}
+
+var simpleModes = []struct {
+	mode  sgr.Mode
+	class string
+	style string
+}{
+	{sgr.Bold, "bold", "font-weight:bold"},
+	{sgr.Italic, "italic", "font-style:italic"},
+	{sgr.SlowBlink, "blink", ""},
+	{sgr.RapidBlink, "rapid-blink", ""},
+	{sgr.Framed, "framed", "outline:1px solid"},
+	{sgr.Encircled, "encircled", "border:1px solid;border-radius:0.5em"},
+	{sgr.Superscript, "superscript", "vertical-align:super;font-size:smaller"},
+	{sgr.Subscript, "subscript", "vertical-align:sub;font-size:smaller"},
+}
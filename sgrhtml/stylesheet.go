@@ -0,0 +1,106 @@
+package sgrhtml
+
+import (
+	"strconv"
+
+	"github.com/pamburus/go-ansi-esc/sgr"
+)
+
+// Stylesheet returns CSS stylesheet defining all classes used in Classes output mode
+// for the palette, default colors and class prefix set by the given options.
+func Stylesheet(options ...Option) string {
+	o := newOptions(options)
+	buf := make([]byte, 0, 32*1024)
+
+	rule := func(selector string, declarations ...string) {
+		buf = append(buf, selector...)
+		buf = append(buf, '{')
+		for i, declaration := range declarations {
+			if i != 0 {
+				buf = append(buf, ';')
+			}
+			buf = append(buf, declaration...)
+		}
+		buf = append(buf, "}\n"...)
+	}
+
+	class := func(names ...string) string {
+		result := make([]byte, 0, 64)
+		for _, name := range names {
+			result = append(result, '.')
+			result = append(result, o.prefix...)
+			result = append(result, name...)
+		}
+
+		return string(result)
+	}
+
+	for _, mode := range simpleModes {
+		switch mode.class {
+		case "blink":
+			rule(class(mode.class), "animation:"+o.prefix+"blink 1s step-end infinite")
+		case "rapid-blink":
+			rule(class(mode.class), "animation:"+o.prefix+"blink 0.5s step-end infinite")
+		default:
+			rule(class(mode.class), mode.style)
+		}
+	}
+	rule("@keyframes "+o.prefix+"blink", "50%{opacity:0}")
+
+	for i := 0; i != len(o.palette); i++ {
+		color := string(appendColor(nil, o.palette[i]))
+		index := strconv.Itoa(i)
+		rule(class("fg-"+index), "color:"+color)
+		rule(class("bg-"+index), "background-color:"+color)
+		rule(class("ul-"+index), "text-decoration-color:"+color)
+	}
+
+	rule(class("inverse-fg"), "color:"+string(appendColor(nil, o.background)))
+	rule(class("inverse-bg"), "background-color:"+string(appendColor(nil, o.foreground)))
+	rule(class("concealed"), "color:transparent")
+
+	// Faint text color is blended with the default background color
+	// because the actual background color is not known in the stylesheet.
+	faint := func(color sgr.RGBColor) string {
+		return "color:" + string(appendColor(nil, blend(color, o.background)))
+	}
+	rule(class("faint"), faint(o.foreground))
+	rule(class("faint", "inverse-fg"), "color:"+string(appendColor(nil, blend(o.background, o.foreground))))
+	for i := 0; i != len(o.palette); i++ {
+		rule(class("faint", "fg-"+strconv.Itoa(i)), faint(o.palette[i]))
+	}
+
+	underlines := []*underline{nil}
+	for i := range underlineKinds {
+		underlines = append(underlines, &underlineKinds[i])
+	}
+
+	for _, underline := range underlines {
+		for _, overline := range []bool{false, true} {
+			for _, crossedOut := range []bool{false, true} {
+				var names []string
+				if underline != nil {
+					names = append(names, underline.class)
+				}
+				if overline {
+					names = append(names, "overline")
+				}
+				if crossedOut {
+					names = append(names, "crossed-out")
+				}
+				if len(names) == 0 {
+					continue
+				}
+
+				lines := appendDecorationLines([]byte("text-decoration-line:"), underline != nil, overline, crossedOut)
+				declarations := []string{string(lines)}
+				if underline != nil && underline.style != "" {
+					declarations = append(declarations, "text-decoration-style:"+underline.style)
+				}
+				rule(class(names...), declarations...)
+			}
+		}
+	}
+
+	return string(buf)
+}
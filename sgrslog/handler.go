@@ -0,0 +1,346 @@
+// Package sgrslog provides a log/slog handler producing colored human-readable output
+// using CSI/SGR sequences with styles defined by a theme.
+//
+// Each record is rendered as a single line like
+//
+//	2024-01-02 15:04:05.000 |INF| server: listening addr=:8080 tls.enabled=true
+//
+// where the logger name is taken from the top-level attribute with LoggerKey key,
+// and attributes of nested groups are prefixed with the group names.
+// Messages, keys and string values are quoted if they contain characters that could make the line ambiguous,
+// like line breaks, '=' or '"', except that messages may contain plain spaces unquoted.
+package sgrslog
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"strconv"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/pamburus/go-ansi-esc/sgr"
+)
+
+// ---
+
+// LoggerKey is the key of the top-level attribute that is rendered as the logger name.
+const LoggerKey = "logger"
+
+// maxPooledBufferSize is the maximum capacity of a buffer that is returned to the pool for reuse.
+const maxPooledBufferSize = 64 << 10
+
+// ---
+
+// NewHandler constructs a new Handler writing records to the target writer.
+func NewHandler(target io.Writer, opts ...Option) *Handler {
+	o := &options{
+		level:      slog.LevelInfo,
+		theme:      DefaultTheme,
+		timeFormat: DefaultTimeFormat,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	var profile sgr.ColorProfile
+	if o.profile != nil {
+		profile = *o.profile
+	} else {
+		profile = sgr.DetectColorProfile(target)
+	}
+
+	h := &Handler{
+		o:       o,
+		profile: profile,
+		target:  target,
+		mu:      &sync.Mutex{},
+		pool:    &sync.Pool{},
+	}
+	h.pool.New = func() any {
+		out := &output{}
+		out.buf.Grow(256)
+		out.w = sgr.NewWriter(&out.buf, sgr.WithColorProfile(h.profile), sgr.WithTheme(h.o.theme))
+
+		return out
+	}
+
+	return h
+}
+
+// ---
+
+// Handler is a slog.Handler that renders records to the target writer using styles defined by a theme.
+// It is safe for concurrent use.
+// Buffers used for rendering records are reused between calls and shared with derived handlers.
+type Handler struct {
+	o       *options
+	profile sgr.ColorProfile
+	target  io.Writer
+	mu      *sync.Mutex
+	pool    *sync.Pool
+	logger  string
+	prefix  string
+	attrs   []prefixedAttr
+}
+
+// Enabled reports whether the handler handles records at the given level.
+func (h *Handler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.o.level.Level()
+}
+
+// WithAttrs returns a new Handler whose attributes consist of both the receiver's attributes and the arguments.
+func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+
+	result := *h
+	result.attrs = append(h.attrs[:len(h.attrs):len(h.attrs)], make([]prefixedAttr, 0, len(attrs))...)
+	for _, attr := range attrs {
+		if h.prefix == "" && attr.Key == LoggerKey {
+			result.logger = attr.Value.Resolve().String()
+
+			continue
+		}
+
+		result.attrs = append(result.attrs, prefixedAttr{h.prefix, attr})
+	}
+
+	return &result
+}
+
+// WithGroup returns a new Handler with the given group appended to the receiver's existing groups.
+func (h *Handler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	result := *h
+	result.prefix = h.prefix + name + "."
+
+	return &result
+}
+
+// Handle renders the record and writes it to the target writer using a single Write call.
+func (h *Handler) Handle(_ context.Context, record slog.Record) error {
+	out := h.pool.Get().(*output)
+	defer h.release(out)
+
+	r := renderer{
+		h: h,
+		w: out.w,
+	}
+
+	logger := h.logger
+	if h.prefix == "" {
+		record.Attrs(func(attr slog.Attr) bool {
+			if attr.Key == LoggerKey {
+				logger = attr.Value.Resolve().String()
+			}
+
+			return true
+		})
+	}
+
+	if !record.Time.IsZero() {
+		r.role("time", record.Time.AppendFormat(r.scratch[:0], h.o.timeFormat))
+		r.text(" ")
+	}
+
+	r.level(record.Level)
+
+	if logger != "" {
+		r.text(" ")
+		r.role("logger", []byte(logger))
+		r.role("punctuation", []byte(":"))
+	}
+
+	if record.Message != "" {
+		r.text(" ")
+		r.role("message", appendMessage(r.scratch[:0], record.Message))
+	}
+
+	for _, attr := range h.attrs {
+		r.attr(attr.prefix, attr.attr)
+	}
+
+	record.Attrs(func(attr slog.Attr) bool {
+		if h.prefix != "" || attr.Key != LoggerKey {
+			r.attr(h.prefix, attr)
+		}
+
+		return true
+	})
+
+	r.text("\n")
+
+	err := r.w.Flush()
+	if err != nil {
+		return err
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	_, err = h.target.Write(out.buf.Bytes())
+
+	return err
+}
+
+// release returns the output to the pool unless its buffer has grown too large to be worth keeping.
+func (h *Handler) release(out *output) {
+	if out.buf.Cap() > maxPooledBufferSize {
+		return
+	}
+
+	out.buf.Reset()
+	out.w.Reset()
+	h.pool.Put(out)
+}
+
+// ---
+
+// output is a buffer with a writer over it that are reused between records.
+type output struct {
+	buf bytes.Buffer
+	w   *sgr.Writer
+}
+
+// ---
+
+type prefixedAttr struct {
+	prefix string
+	attr   slog.Attr
+}
+
+// ---
+
+type renderer struct {
+	h       *Handler
+	w       *sgr.Writer
+	scratch [64]byte
+}
+
+func (r *renderer) role(role string, text []byte) {
+	r.w.PushRole(role)
+	_, _ = r.w.Write(text)
+	r.w.PopRole()
+}
+
+func (r *renderer) text(text string) {
+	_, _ = r.w.WriteString(text)
+}
+
+func (r *renderer) level(level slog.Level) {
+	role, name := "level.error", "ERR"
+
+	switch {
+	case level < slog.LevelInfo:
+		role, name = "level.debug", "DBG"
+	case level < slog.LevelWarn:
+		role, name = "level.info", "INF"
+	case level < slog.LevelError:
+		role, name = "level.warn", "WRN"
+	}
+
+	switch level {
+	case slog.LevelDebug, slog.LevelInfo, slog.LevelWarn, slog.LevelError:
+	default:
+		name = level.String()
+	}
+
+	r.role("punctuation", []byte("|"))
+	r.role(role, []byte(name))
+	r.role("punctuation", []byte("|"))
+}
+
+func (r *renderer) attr(prefix string, attr slog.Attr) {
+	attr.Value = attr.Value.Resolve()
+
+	if attr.Value.Kind() == slog.KindGroup {
+		if attr.Key != "" {
+			prefix += attr.Key + "."
+		}
+		for _, attr := range attr.Value.Group() {
+			r.attr(prefix, attr)
+		}
+
+		return
+	}
+
+	if attr.Equal(slog.Attr{}) {
+		return
+	}
+
+	r.text(" ")
+	r.role("key", appendString(r.scratch[:0], prefix+attr.Key))
+	r.role("punctuation", []byte("="))
+	r.value(attr.Value)
+}
+
+func (r *renderer) value(value slog.Value) {
+	buf := r.scratch[:0]
+
+	switch value.Kind() {
+	case slog.KindString:
+		r.role("value.string", appendString(buf, value.String()))
+	case slog.KindInt64:
+		r.role("value.number", strconv.AppendInt(buf, value.Int64(), 10))
+	case slog.KindUint64:
+		r.role("value.number", strconv.AppendUint(buf, value.Uint64(), 10))
+	case slog.KindFloat64:
+		r.role("value.number", strconv.AppendFloat(buf, value.Float64(), 'g', -1, 64))
+	case slog.KindDuration:
+		r.role("value.number", append(buf, value.Duration().String()...))
+	case slog.KindBool:
+		r.role("value.bool", strconv.AppendBool(buf, value.Bool()))
+	case slog.KindTime:
+		r.role("value.time", value.Time().AppendFormat(buf, time.RFC3339Nano))
+	default:
+		if err, ok := value.Any().(error); ok {
+			r.role("value.error", appendString(buf, err.Error()))
+		} else {
+			r.role("value.any", appendString(buf, value.String()))
+		}
+	}
+}
+
+// ---
+
+// appendString appends s to buf quoting it if it is empty or contains characters that could make the output ambiguous.
+func appendString(buf []byte, s string) []byte {
+	if needsQuoting(s, false) {
+		return strconv.AppendQuote(buf, s)
+	}
+
+	return append(buf, s...)
+}
+
+// appendMessage appends s to buf the same way as appendString does but allows plain spaces in it unquoted.
+func appendMessage(buf []byte, s string) []byte {
+	if needsQuoting(s, true) {
+		return strconv.AppendQuote(buf, s)
+	}
+
+	return append(buf, s...)
+}
+
+func needsQuoting(s string, allowSpace bool) bool {
+	if s == "" {
+		return true
+	}
+
+	for _, r := range s {
+		switch {
+		case r == ' ' && allowSpace:
+		case r == '=', r == '"', r == utf8.RuneError, unicode.IsSpace(r), !unicode.IsPrint(r):
+			return true
+		}
+	}
+
+	return false
+}
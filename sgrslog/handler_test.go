@@ -0,0 +1,107 @@
+package sgrslog_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	. "github.com/pamburus/go-tst/tst"
+
+	"github.com/pamburus/go-ansi-esc/sgr"
+	"github.com/pamburus/go-ansi-esc/sgrslog"
+)
+
+func TestHandler(tt *testing.T) {
+	t := New(tt)
+
+	ts := time.Date(2024, 1, 2, 15, 4, 5, 6000000, time.UTC)
+	ctx := context.Background()
+
+	handle := func(t Test, h slog.Handler, level slog.Level, msg string, attrs ...slog.Attr) {
+		record := slog.NewRecord(ts, level, msg, 0)
+		record.AddAttrs(attrs...)
+		t.Expect(h.Handle(ctx, record)).ToSucceed()
+	}
+
+	t.Run("Plain", func(t Test) {
+		buf := bytes.NewBuffer(nil)
+		h := sgrslog.NewHandler(buf, sgrslog.WithColorProfile(sgr.ColorProfileNone), sgrslog.WithLevel(slog.LevelDebug))
+
+		handle(t, h, slog.LevelInfo, "started",
+			slog.String(sgrslog.LoggerKey, "server"),
+			slog.String("addr", ":8080"),
+			slog.Group("tls", slog.Bool("enabled", true), slog.Group("", slog.Int("port", 443))),
+			slog.Group("empty"),
+		)
+		handle(t, h.WithAttrs([]slog.Attr{slog.String(sgrslog.LoggerKey, "db")}).WithGroup("q").WithAttrs([]slog.Attr{slog.Duration("took", time.Second)}),
+			slog.LevelWarn+1, "slow query", slog.String("sql", "select 1"), slog.Any("err", errors.New("timeout")))
+		handle(t, h.WithGroup(""), slog.LevelDebug, "", slog.Float64("f", 1.5), slog.Uint64("u", 7), slog.Time("at", ts), slog.Any("v", []int{1}))
+		handle(t, h, slog.LevelError, "failed", slog.String("s", ""), slog.String("q", `a"b`))
+		handle(t, h, slog.LevelInfo, "line\nforged=1", slog.String("bad key", "v"), slog.String("k=v", "x"))
+
+		t.Expect(buf.String()).ToEqual(
+			"2024-01-02 15:04:05.006 |INF| server: started addr=:8080 tls.enabled=true tls.port=443\n" +
+				"2024-01-02 15:04:05.006 |WARN+1| db: slow query q.took=1s q.sql=\"select 1\" q.err=timeout\n" +
+				"2024-01-02 15:04:05.006 |DBG| f=1.5 u=7 at=2024-01-02T15:04:05.006Z v=[1]\n" +
+				"2024-01-02 15:04:05.006 |ERR| failed s=\"\" q=\"a\\\"b\"\n" +
+				"2024-01-02 15:04:05.006 |INF| \"line\\nforged=1\" \"bad key\"=v \"k=v\"=x\n",
+		)
+	})
+
+	t.Run("Colors", func(t Test) {
+		buf := bytes.NewBuffer(nil)
+		h := sgrslog.NewHandler(buf,
+			sgrslog.WithColorProfile(sgr.ColorProfile16),
+			sgrslog.WithTimeFormat(time.Kitchen),
+			sgrslog.WithTheme(sgr.Theme{
+				"level":       {Modes: sgr.Bold.ModeSet()},
+				"level.error": {Foreground: sgr.Red.Color()},
+				"key":         {Foreground: sgr.RGB(0, 255, 0).Color()},
+			}),
+		)
+
+		handle(t, h, slog.LevelError, "failed", slog.String("k", "v"))
+		handle(t, h.WithAttrs([]slog.Attr{slog.Int("n", 1)}), slog.LevelError, "again")
+		t.Expect(buf.String()).ToEqual(
+			"3:04PM |\x1b[31mERR\x1b[0m| failed \x1b[92mk\x1b[0m=v\n" +
+				"3:04PM |\x1b[31mERR\x1b[0m| again \x1b[92mn\x1b[0m=1\n",
+		)
+	})
+
+	t.Run("Level", func(t Test) {
+		h := sgrslog.NewHandler(bytes.NewBuffer(nil))
+		t.Expect(h.Enabled(ctx, slog.LevelDebug)).ToEqual(false)
+		t.Expect(h.Enabled(ctx, slog.LevelInfo)).ToEqual(true)
+		t.Expect(sgrslog.NewHandler(bytes.NewBuffer(nil), sgrslog.WithLevel(slog.LevelError)).Enabled(ctx, slog.LevelWarn)).ToEqual(false)
+
+		h = sgrslog.NewHandler(bytes.NewBuffer(nil), sgrslog.WithLevel(nil))
+		t.Expect(h.Enabled(ctx, slog.LevelDebug)).ToEqual(false)
+		t.Expect(h.Enabled(ctx, slog.LevelInfo)).ToEqual(true)
+	})
+
+	t.Run("Detect", func(t Test) {
+		t.Setenv("NO_COLOR", "")
+		t.Setenv("FORCE_COLOR", "1")
+		buf := bytes.NewBuffer(nil)
+		slog.New(sgrslog.NewHandler(buf)).Info("x")
+		t.Expect(bytes.Contains(buf.Bytes(), []byte("\x1b[36mINF"))).ToBeTrue()
+	})
+
+	t.Run("Error", func(t Test) {
+		h := sgrslog.NewHandler(failingWriter{}, sgrslog.WithColorProfile(sgr.ColorProfileNone))
+		t.Expect(h.Handle(ctx, slog.NewRecord(ts, slog.LevelInfo, "x", 0))).ToFailWith(errFailingWriter)
+	})
+}
+
+// ---
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errFailingWriter
+}
+
+var errFailingWriter = errors.New("failing writer error")
@@ -0,0 +1,90 @@
+package sgrslog
+
+import (
+	"log/slog"
+
+	"github.com/pamburus/go-ansi-esc/sgr"
+)
+
+// ---
+
+// DefaultTimeFormat is the default layout used to format record time.
+const DefaultTimeFormat = "2006-01-02 15:04:05.000"
+
+// DefaultTheme is the default theme used by Handler.
+//
+// Handler uses the following roles:
+//   - "time" for record time;
+//   - "level.debug", "level.info", "level.warn" and "level.error" for record level;
+//   - "logger" for logger name;
+//   - "message" for record message;
+//   - "key" for attribute keys including group prefixes;
+//   - "value.string", "value.number", "value.bool", "value.time", "value.error" and "value.any" for attribute values;
+//   - "punctuation" for separators.
+var DefaultTheme = sgr.Theme{
+	"time":         {Foreground: sgr.BrightBlack.Color()},
+	"level.debug":  {Foreground: sgr.Magenta.Color()},
+	"level.info":   {Foreground: sgr.Cyan.Color()},
+	"level.warn":   {Foreground: sgr.Yellow.Color()},
+	"level.error":  {Foreground: sgr.BrightRed.Color(), Modes: sgr.Bold.ModeSet()},
+	"logger":       {Foreground: sgr.BrightBlack.Color()},
+	"message":      {Foreground: sgr.BrightWhite.Color()},
+	"key":          {Foreground: sgr.Green.Color()},
+	"value.string": {Foreground: sgr.BrightCyan.Color()},
+	"value.number": {Foreground: sgr.BrightBlue.Color()},
+	"value.bool":   {Foreground: sgr.BrightRed.Color()},
+	"value.time":   {Foreground: sgr.BrightMagenta.Color()},
+	"value.error":  {Foreground: sgr.Red.Color()},
+	"punctuation":  {Foreground: sgr.BrightBlack.Color()},
+}
+
+// ---
+
+// Option is an option that can be passed to NewHandler to customize its behavior.
+type Option func(*options)
+
+// WithLevel returns an Option that sets the minimum level of records to be handled.
+// Default is slog.LevelInfo, which is also used if level is nil.
+func WithLevel(level slog.Leveler) Option {
+	if level == nil {
+		level = slog.LevelInfo
+	}
+
+	return func(o *options) {
+		o.level = level
+	}
+}
+
+// WithTheme returns an Option that sets the theme.
+// Default is DefaultTheme.
+func WithTheme(theme sgr.Theme) Option {
+	return func(o *options) {
+		o.theme = theme
+	}
+}
+
+// WithColorProfile returns an Option that sets the color profile of the target writer.
+// ColorProfileNone makes Handler produce plain text.
+// Default is the color profile detected using sgr.DetectColorProfile.
+func WithColorProfile(profile sgr.ColorProfile) Option {
+	return func(o *options) {
+		o.profile = &profile
+	}
+}
+
+// WithTimeFormat returns an Option that sets the layout used to format record time.
+// Default is DefaultTimeFormat.
+func WithTimeFormat(layout string) Option {
+	return func(o *options) {
+		o.timeFormat = layout
+	}
+}
+
+// ---
+
+type options struct {
+	level      slog.Leveler
+	theme      sgr.Theme
+	profile    *sgr.ColorProfile
+	timeFormat string
+}
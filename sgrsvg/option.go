@@ -0,0 +1,115 @@
+package sgrsvg
+
+import "github.com/pamburus/go-ansi-esc/sgr"
+
+// ---
+
+// DefaultTheme is the theme used by default.
+var DefaultTheme = Theme{
+	Palette:    sgr.DefaultPalette,
+	Foreground: sgr.RGB(0xe5, 0xe5, 0xe5),
+	Background: sgr.RGB(0x1e, 0x1e, 0x1e),
+	Chrome:     sgr.RGB(0x3c, 0x3c, 0x3c),
+}
+
+// Theme defines colors used for rendering.
+type Theme struct {
+	// Palette is used to resolve BasicColor and PaletteColor values.
+	Palette sgr.Palette
+	// Foreground is the terminal default foreground color.
+	Foreground sgr.RGBColor
+	// Background is the terminal default background color.
+	Background sgr.RGBColor
+	// Chrome is the color of the window title bar.
+	Chrome sgr.RGBColor
+}
+
+// ---
+
+// Option is an option that can be passed to Render to customize the output.
+type Option func(*options)
+
+// WithTheme returns an Option that sets the theme.
+// Default is DefaultTheme.
+func WithTheme(theme Theme) Option {
+	return func(o *options) {
+		o.theme = theme
+	}
+}
+
+// WithFont returns an Option that sets font family and font size in pixels.
+// Default is a list of common monospace fonts and 14.
+func WithFont(family string, size float64) Option {
+	return func(o *options) {
+		o.fontFamily = family
+		o.fontSize = size
+	}
+}
+
+// WithCellSize returns an Option that sets width of a column and height of a line in pixels.
+// Column width should match the advance width of the font glyphs to get the best result.
+// Default is 8.4 and 18 which matches most monospace fonts of size 14.
+func WithCellSize(columnWidth, lineHeight float64) Option {
+	return func(o *options) {
+		o.columnWidth = columnWidth
+		o.lineHeight = lineHeight
+	}
+}
+
+// WithColumns returns an Option that sets the minimum number of columns in the rendered screen.
+// By default the screen is as wide as the longest line.
+func WithColumns(columns int) Option {
+	return func(o *options) {
+		o.columns = columns
+	}
+}
+
+// WithPadding returns an Option that sets the padding around the screen in pixels.
+// Default is 12.
+func WithPadding(padding float64) Option {
+	return func(o *options) {
+		o.padding = padding
+	}
+}
+
+// WithWindow returns an Option that enables or disables rendering of the window title bar
+// with the given title which can be empty.
+// By default the title bar is rendered without a title.
+func WithWindow(enabled bool, title string) Option {
+	return func(o *options) {
+		o.window = enabled
+		o.title = title
+	}
+}
+
+// ---
+
+type options struct {
+	theme       Theme
+	fontFamily  string
+	fontSize    float64
+	columnWidth float64
+	lineHeight  float64
+	columns     int
+	padding     float64
+	window      bool
+	title       string
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{
+		theme:       DefaultTheme,
+		fontFamily:  "ui-monospace, SFMono-Regular, Menlo, Consolas, 'DejaVu Sans Mono', monospace",
+		fontSize:    14,
+		columnWidth: 8.4,
+		lineHeight:  18,
+		padding:     12,
+		window:      true,
+	}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o
+}
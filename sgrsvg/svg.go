@@ -0,0 +1,396 @@
+// Package sgrsvg provides facilities for rendering text containing CSI/SGR ANSI Escape Sequences
+// to a standalone SVG image that looks like a terminal window.
+//
+// Text is laid out in a grid of monospace cells, each line is rendered as a text element
+// with a tspan element for each piece of text having the same style.
+// Background colors, underlines with their own color, overlines, crossed out text and frames
+// are rendered as separate shapes, so that they look the same regardless of the font and renderer used.
+// Blinking is not supported. Escape sequences other than SGR are ignored.
+package sgrsvg
+
+import (
+	"bytes"
+	"math"
+	"strconv"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/rivo/uniseg"
+
+	"github.com/pamburus/go-ansi-esc/sgr"
+)
+
+// ---
+
+// Render renders data containing CSI/SGR sequences to a standalone SVG image.
+func Render(data []byte, options ...Option) []byte {
+	o := newOptions(options)
+	s := parse(data)
+	r := &renderer{o: o, buf: make([]byte, 0, 1024+len(data)*8)}
+
+	return r.render(s)
+}
+
+// ---
+
+type run struct {
+	col   int
+	width int
+	text  []byte
+	style sgr.Style
+}
+
+type screen struct {
+	lines [][]run
+	cols  int
+}
+
+func parse(data []byte) screen {
+	var interp sgr.Interpreter
+	lines := [][]run{nil}
+	col := 0
+	cols := 0
+
+	add := func(text []byte, width int) {
+		i := len(lines) - 1
+		style := interp.Style()
+		if n := len(lines[i]); n != 0 {
+			last := &lines[i][n-1]
+			if last.style == style && last.col+last.width == col {
+				last.text = append(last.text, text...)
+				last.width += width
+				col += width
+
+				return
+			}
+		}
+
+		lines[i] = append(lines[i], run{col, width, append([]byte(nil), text...), style})
+		col += width
+	}
+
+	for len(data) != 0 {
+		n, token, _ := sgr.ScanTokens(data, true)
+		data = data[n:]
+
+		if sgr.IsEscape(token) {
+			if sgr.IsSequence(token) {
+				seq, _ := sgr.ParseSequence(token)
+				interp.Apply(seq)
+			}
+
+			continue
+		}
+
+		for len(token) != 0 {
+			r, size := utf8.DecodeRune(token)
+			switch {
+			case r == '\n':
+				cols = max(cols, col)
+				lines = append(lines, nil)
+				col = 0
+			case r == '\t':
+				add([]byte("        ")[:tabWidth-col%tabWidth], tabWidth-col%tabWidth)
+			case r == utf8.RuneError && size == 1, unicode.IsControl(r):
+			default:
+				cluster, _, _, _ := uniseg.FirstGraphemeCluster(token, -1)
+				add(cluster, sgr.Width(cluster))
+				size = len(cluster)
+			}
+			token = token[size:]
+		}
+	}
+
+	cols = max(cols, col)
+	if len(lines) > 1 && len(lines[len(lines)-1]) == 0 {
+		lines = lines[:len(lines)-1]
+	}
+
+	return screen{lines, cols}
+}
+
+// ---
+
+type renderer struct {
+	o   *options
+	buf []byte
+}
+
+func (r *renderer) render(s screen) []byte {
+	o := r.o
+	cols := max(s.cols, o.columns)
+	top := o.padding
+	if o.window {
+		top += barHeight
+	}
+	width := 2*o.padding + float64(cols)*o.columnWidth
+	height := top + float64(len(s.lines))*o.lineHeight + o.padding
+
+	r.str(`<svg xmlns="http://www.w3.org/2000/svg" width="`).num(width).str(`" height="`).num(height)
+	r.str(`" viewBox="0 0 `).num(width).str(" ").num(height).str(`">`).nl()
+	r.str(`<rect width="`).num(width).str(`" height="`).num(height).str(`" rx="6" fill="`).color(o.theme.Background).str(`"/>`).nl()
+
+	if o.window {
+		r.window(width)
+	}
+
+	r.str(`<g transform="translate(`).num(o.padding).str(",").num(top).str(`)">`).nl()
+	for row, line := range s.lines {
+		r.backgrounds(row, line)
+	}
+	r.str(`<g font-family="`).escaped([]byte(o.fontFamily)).str(`" font-size="`).num(o.fontSize)
+	r.str(`" fill="`).color(o.theme.Foreground).str(`" xml:space="preserve">`).nl()
+	for row, line := range s.lines {
+		r.text(row, line)
+	}
+	r.str(`</g>`).nl()
+	for row, line := range s.lines {
+		r.decorations(row, line)
+	}
+	r.str(`</g>`).nl()
+	r.str(`</svg>`).nl()
+
+	return r.buf
+}
+
+func (r *renderer) window(width float64) {
+	o := r.o
+	r.str(`<path d="M0,6a6,6 0 0 1 6,-6h`).num(width - 12).str(`a6,6 0 0 1 6,6v`).num(barHeight - 6).str(`h-`).num(width)
+	r.str(`z" fill="`).color(o.theme.Chrome).str(`"/>`).nl()
+
+	for i, color := range []sgr.RGBColor{0xff5f57, 0xfebc2e, 0x28c840} {
+		r.str(`<circle cx="`).num(float64(14 + i*20)).str(`" cy="`).num(barHeight / 2).str(`" r="6" fill="`).color(color).str(`"/>`).nl()
+	}
+
+	if o.title != "" {
+		r.str(`<text x="`).num(width / 2).str(`" y="`).num(barHeight/2 + 4).str(`" text-anchor="middle" font-family="sans-serif" font-size="12" fill="`)
+		r.color(o.theme.Foreground).str(`">`).escaped([]byte(o.title)).str(`</text>`).nl()
+	}
+}
+
+func (r *renderer) backgrounds(row int, line []run) {
+	for _, run := range line {
+		_, bg, ok := r.colors(run.style)
+		if !ok {
+			continue
+		}
+
+		r.rect(run.col, run.width, float64(row)*r.o.lineHeight, r.o.lineHeight, bg)
+	}
+}
+
+func (r *renderer) text(row int, line []run) {
+	o := r.o
+	started := false
+
+	for _, run := range line {
+		if run.style.Modes.Has(sgr.Concealed) || len(bytes.TrimSpace(run.text)) == 0 {
+			continue
+		}
+
+		if !started {
+			r.str(`<text y="`).num(float64(row)*o.lineHeight + (o.lineHeight+o.fontSize*0.7)/2).str(`">`)
+			started = true
+		}
+
+		fg, _, _ := r.colors(run.style)
+		r.str(`<tspan x="`).num(float64(run.col) * o.columnWidth).str(`"`)
+		if fg != o.theme.Foreground {
+			r.str(` fill="`).color(fg).str(`"`)
+		}
+		if run.style.Modes.Has(sgr.Bold) {
+			r.str(` font-weight="bold"`)
+		}
+		if run.style.Modes.Has(sgr.Italic) {
+			r.str(` font-style="italic"`)
+		}
+		switch {
+		case run.style.Modes.Has(sgr.Superscript):
+			r.str(` baseline-shift="super" font-size="smaller"`)
+		case run.style.Modes.Has(sgr.Subscript):
+			r.str(` baseline-shift="sub" font-size="smaller"`)
+		}
+		r.str(`>`).escaped(run.text).str(`</tspan>`)
+	}
+
+	if started {
+		r.str(`</text>`).nl()
+	}
+}
+
+func (r *renderer) decorations(row int, line []run) {
+	o := r.o
+	y := float64(row) * o.lineHeight
+	baseline := y + (o.lineHeight+o.fontSize*0.7)/2
+
+	for _, run := range line {
+		modes := run.style.Modes
+		fg, _, _ := r.colors(run.style)
+
+		if modes&underlineModes != 0 {
+			ul := fg
+			if c, ok := o.theme.Palette.Resolve(run.style.UnderlineColor); ok {
+				ul = c
+			}
+
+			switch {
+			case modes.Has(sgr.CurlyUnderlined):
+				r.wave(run.col, run.width, baseline+2.5, ul)
+			case modes.Has(sgr.DottedUnderlined):
+				r.line(run.col, run.width, baseline+2.5, "1 1", ul)
+			case modes.Has(sgr.DashedUnderlined):
+				r.line(run.col, run.width, baseline+2.5, "3 2", ul)
+			default:
+				r.rect(run.col, run.width, baseline+2, 1, ul)
+				if modes.Has(sgr.DoublyUnderlined) {
+					r.rect(run.col, run.width, baseline+4, 1, ul)
+				}
+			}
+		}
+		if modes.Has(sgr.Overlined) {
+			r.rect(run.col, run.width, y+1, 1, fg)
+		}
+		if modes.Has(sgr.CrossedOut) {
+			r.rect(run.col, run.width, baseline-o.fontSize*0.3, 1, fg)
+		}
+		if modes.Has(sgr.Framed) || modes.Has(sgr.Encircled) {
+			r.str(`<rect x="`).num(float64(run.col)*o.columnWidth + 0.5).str(`" y="`).num(y + 0.5)
+			r.str(`" width="`).num(float64(run.width)*o.columnWidth - 1).str(`" height="`).num(o.lineHeight - 1)
+			if modes.Has(sgr.Encircled) {
+				r.str(`" rx="`).num(o.lineHeight / 2)
+			}
+			r.str(`" fill="none" stroke="`).color(fg).str(`"/>`).nl()
+		}
+	}
+}
+
+// colors returns effective foreground and background colors of the style
+// and true if the background color differs from the terminal default background color.
+func (r *renderer) colors(s sgr.Style) (sgr.RGBColor, sgr.RGBColor, bool) {
+	theme := &r.o.theme
+	fg := resolve(&theme.Palette, s.Foreground, theme.Foreground)
+	bg := resolve(&theme.Palette, s.Background, theme.Background)
+	custom := !s.Background.IsDefaultColor()
+
+	if s.Modes.Has(sgr.Reversed) {
+		fg, bg = bg, fg
+		custom = true
+	}
+
+	if s.Modes.Has(sgr.Faint) {
+		fg = blend(fg, bg)
+	}
+
+	return fg, bg, custom
+}
+
+func (r *renderer) rect(col, width int, y, height float64, color sgr.RGBColor) {
+	o := r.o
+	r.str(`<rect x="`).num(float64(col) * o.columnWidth).str(`" y="`).num(y)
+	r.str(`" width="`).num(float64(width) * o.columnWidth).str(`" height="`).num(height)
+	r.str(`" fill="`).color(color).str(`"/>`).nl()
+}
+
+// line draws a horizontal dashed line with its center at y.
+func (r *renderer) line(col, width int, y float64, dashes string, color sgr.RGBColor) {
+	o := r.o
+	r.str(`<line x1="`).num(float64(col) * o.columnWidth).str(`" y1="`).num(y)
+	r.str(`" x2="`).num(float64(col+width) * o.columnWidth).str(`" y2="`).num(y)
+	r.str(`" stroke="`).color(color).str(`" stroke-dasharray="`).str(dashes).str(`"/>`).nl()
+}
+
+// wave draws a horizontal wavy line with its center at y and a period of half a column.
+func (r *renderer) wave(col, width int, y float64, color sgr.RGBColor) {
+	o := r.o
+	step := o.columnWidth / 4
+	r.str(`<polyline points="`)
+	for i := 0; i <= width*4; i++ {
+		if i != 0 {
+			r.str(" ")
+		}
+		r.num(float64(col)*o.columnWidth + float64(i)*step).str(",").num(y + float64(i%2) - 0.5)
+	}
+	r.str(`" fill="none" stroke="`).color(color).str(`"/>`).nl()
+}
+
+func (r *renderer) str(s string) *renderer {
+	r.buf = append(r.buf, s...)
+
+	return r
+}
+
+func (r *renderer) nl() {
+	r.buf = append(r.buf, '\n')
+}
+
+func (r *renderer) num(v float64) *renderer {
+	r.buf = strconv.AppendFloat(r.buf, math.Round(v*100)/100, 'f', -1, 64)
+
+	return r
+}
+
+func (r *renderer) color(color sgr.RGBColor) *renderer {
+	const digits = "0123456789abcdef"
+
+	r.buf = append(r.buf, '#',
+		digits[color.R()>>4], digits[color.R()&0xF],
+		digits[color.G()>>4], digits[color.G()&0xF],
+		digits[color.B()>>4], digits[color.B()&0xF],
+	)
+
+	return r
+}
+
+func (r *renderer) escaped(text []byte) *renderer {
+	for _, c := range text {
+		switch c {
+		case '&':
+			r.buf = append(r.buf, "&amp;"...)
+		case '<':
+			r.buf = append(r.buf, "&lt;"...)
+		case '>':
+			r.buf = append(r.buf, "&gt;"...)
+		case '"':
+			r.buf = append(r.buf, "&#34;"...)
+		case '\'':
+			r.buf = append(r.buf, "&#39;"...)
+		default:
+			r.buf = append(r.buf, c)
+		}
+	}
+
+	return r
+}
+
+// ---
+
+func resolve(palette *sgr.Palette, c sgr.Color, def sgr.RGBColor) sgr.RGBColor {
+	if rgb, ok := palette.Resolve(c); ok {
+		return rgb
+	}
+
+	return def
+}
+
+func blend(a, b sgr.RGBColor) sgr.RGBColor {
+	mix := func(x, y uint8) uint8 {
+		return uint8((uint(x) + uint(y)) / 2)
+	}
+
+	return sgr.RGB(mix(a.R(), b.R()), mix(a.G(), b.G()), mix(a.B(), b.B()))
+}
+
+// ---
+
+const (
+	tabWidth  = 8
+	barHeight = 28.0
+)
+
+var underlineModes = sgr.ModeSetWith(
+	sgr.Underlined,
+	sgr.DoublyUnderlined,
+	sgr.CurlyUnderlined,
+	sgr.DottedUnderlined,
+	sgr.DashedUnderlined,
+)
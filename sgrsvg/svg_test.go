@@ -0,0 +1,94 @@
+package sgrsvg_test
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/pamburus/go-tst/tst"
+
+	"github.com/pamburus/go-ansi-esc/sgr"
+	"github.com/pamburus/go-ansi-esc/sgrsvg"
+)
+
+func TestRender(tt *testing.T) {
+	t := New(tt)
+
+	t.Run("Window", func(t Test) {
+		t.Expect(
+			string(sgrsvg.Render(
+				[]byte("a\x1b[1;31;44mb<\x1b[0m\tc\n\x1b[4;58;5;2mu\x1b[21;53;9md\x1b[0;7;51me\x1b[0;2;3;73mf\x1b[0;8mg\n"),
+				sgrsvg.WithWindow(true, "t&t"),
+			)),
+		).ToEqual(strings.Join([]string{
+			`<svg xmlns="http://www.w3.org/2000/svg" width="99.6" height="88" viewBox="0 0 99.6 88">`,
+			`<rect width="99.6" height="88" rx="6" fill="#1e1e1e"/>`,
+			`<path d="M0,6a6,6 0 0 1 6,-6h87.6a6,6 0 0 1 6,6v22h-99.6z" fill="#3c3c3c"/>`,
+			`<circle cx="14" cy="14" r="6" fill="#ff5f57"/>`,
+			`<circle cx="34" cy="14" r="6" fill="#febc2e"/>`,
+			`<circle cx="54" cy="14" r="6" fill="#28c840"/>`,
+			`<text x="49.8" y="18" text-anchor="middle" font-family="sans-serif" font-size="12" fill="#e5e5e5">t&amp;t</text>`,
+			`<g transform="translate(12,40)">`,
+			`<rect x="8.4" y="0" width="16.8" height="18" fill="#0000ee"/>`,
+			`<rect x="16.8" y="18" width="8.4" height="18" fill="#e5e5e5"/>`,
+			`<g font-family="ui-monospace, SFMono-Regular, Menlo, Consolas, &#39;DejaVu Sans Mono&#39;, monospace" font-size="14" fill="#e5e5e5" xml:space="preserve">`,
+			`<text y="13.9"><tspan x="0">a</tspan><tspan x="8.4" fill="#cd0000" font-weight="bold">b&lt;</tspan><tspan x="25.2">     c</tspan></text>`,
+			`<text y="31.9"><tspan x="0">u</tspan><tspan x="8.4">d</tspan><tspan x="16.8" fill="#1e1e1e">e</tspan>` +
+				`<tspan x="25.2" fill="#818181" font-style="italic" baseline-shift="super" font-size="smaller">f</tspan></text>`,
+			`</g>`,
+			`<rect x="0" y="33.9" width="8.4" height="1" fill="#00cd00"/>`,
+			`<rect x="8.4" y="33.9" width="8.4" height="1" fill="#00cd00"/>`,
+			`<rect x="8.4" y="35.9" width="8.4" height="1" fill="#00cd00"/>`,
+			`<rect x="8.4" y="19" width="8.4" height="1" fill="#e5e5e5"/>`,
+			`<rect x="8.4" y="27.7" width="8.4" height="1" fill="#e5e5e5"/>`,
+			`<rect x="17.3" y="18.5" width="7.4" height="17" fill="none" stroke="#1e1e1e"/>`,
+			`</g>`,
+			`</svg>`,
+			``,
+		}, "\n"))
+	})
+
+	t.Run("Options", func(t Test) {
+		theme := sgrsvg.DefaultTheme
+		theme.Background = sgr.RGB(255, 255, 255)
+		theme.Foreground = sgr.RGB(0, 0, 0)
+
+		t.Expect(
+			string(sgrsvg.Render(
+				[]byte("\x1b[52;74ma\x1b[0m\x00\r"),
+				sgrsvg.WithWindow(false, ""),
+				sgrsvg.WithTheme(theme),
+				sgrsvg.WithFont("mono", 10),
+				sgrsvg.WithCellSize(6, 12),
+				sgrsvg.WithColumns(4),
+				sgrsvg.WithPadding(2),
+			)),
+		).ToEqual(strings.Join([]string{
+			`<svg xmlns="http://www.w3.org/2000/svg" width="28" height="16" viewBox="0 0 28 16">`,
+			`<rect width="28" height="16" rx="6" fill="#ffffff"/>`,
+			`<g transform="translate(2,2)">`,
+			`<g font-family="mono" font-size="10" fill="#000000" xml:space="preserve">`,
+			`<text y="9.5"><tspan x="0" baseline-shift="sub" font-size="smaller">a</tspan></text>`,
+			`</g>`,
+			`<rect x="0.5" y="0.5" width="5" height="11" rx="6" fill="none" stroke="#000000"/>`,
+			`</g>`,
+			`</svg>`,
+			``,
+		}, "\n"))
+	})
+
+	t.Run("UnderlineStyles", func(t Test) {
+		svg := string(sgrsvg.Render(
+			[]byte("\x1b[4:3;58;5;1ma\x1b[4:4mb\x1b[4:5mc"),
+			sgrsvg.WithWindow(false, ""),
+			sgrsvg.WithCellSize(4, 10),
+			sgrsvg.WithColumns(3),
+		))
+		for _, element := range []string{
+			`<polyline points="0,11.9 1,12.9 2,11.9 3,12.9 4,11.9" fill="none" stroke="#cd0000"/>`,
+			`<line x1="4" y1="12.4" x2="8" y2="12.4" stroke="#cd0000" stroke-dasharray="1 1"/>`,
+			`<line x1="8" y1="12.4" x2="12" y2="12.4" stroke="#cd0000" stroke-dasharray="3 2"/>`,
+		} {
+			t.Expect(strings.Contains(svg, element)).ToBeTrue()
+		}
+	})
+}
@@ -0,0 +1,35 @@
+package sgrtemplate
+
+import "github.com/pamburus/go-ansi-esc/sgr"
+
+// ---
+
+// Option is an option that can be passed to FuncMap to customize the output.
+type Option func(*options)
+
+// WithColorProfile returns an Option that sets the color profile all styles are converted to.
+// ColorProfileNone disables styling, so that all functions produce plain text.
+// Default is sgr.ColorProfileTrueColor.
+func WithColorProfile(profile sgr.ColorProfile) Option {
+	return func(o *options) {
+		o.profile = profile
+	}
+}
+
+// ---
+
+type options struct {
+	profile sgr.ColorProfile
+}
+
+func newOptions(opts []Option) options {
+	o := options{
+		profile: sgr.ColorProfileTrueColor,
+	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
@@ -0,0 +1,159 @@
+// Package sgrtemplate provides a function map for text/template and html/template packages
+// that allows templates to style their output using CSI/SGR sequences.
+//
+// Styling functions take the value to be styled as the last argument, so they can be used in pipelines:
+//
+//	{{ .Name | fg "bright-green" }}
+//	{{ style "bold red on blue" (printf "%s: %v" .Key (bold .Value)) }}
+//
+// Styling functions are aware of styles already contained in the value they style,
+// so nested styled blocks do not break the outer style when they end.
+package sgrtemplate
+
+import (
+	"fmt"
+
+	"github.com/pamburus/go-ansi-esc/sgr"
+)
+
+// ---
+
+// FuncMap returns a new function map containing the following functions:
+//   - fg COLOR VALUE: renders VALUE with the foreground color COLOR;
+//   - bg COLOR VALUE: renders VALUE with the background color COLOR;
+//   - ul COLOR VALUE: renders VALUE underlined with the underline color COLOR,
+//     keeping the underline style VALUE may already have, like in {{ ul "red" (style "double-underlined" "x") }};
+//   - bold VALUE: renders VALUE in bold;
+//   - style STYLE VALUE: renders VALUE with STYLE in the form accepted by sgr.ParseStyle, e.g. "bold red on blue";
+//   - reset: renders a sequence resetting all colors and modes to terminal defaults;
+//   - strip VALUE: renders VALUE with all escape sequences removed;
+//   - width VALUE: returns the display width of VALUE ignoring all escape sequences.
+//
+// COLOR can be any text accepted by sgr.Color.UnmarshalText like "red", "bright-red", "#0f" or "#ff8000",
+// an integer palette color index or any sgr.IntoColor value.
+// VALUE can be of any type and is formatted using fmt.Sprint.
+// The result can be passed directly to Funcs method of both text/template and html/template templates.
+func FuncMap(options ...Option) map[string]any {
+	f := funcs{newOptions(options)}
+
+	return map[string]any{
+		"fg":    f.fg,
+		"bg":    f.bg,
+		"ul":    f.ul,
+		"bold":  f.bold,
+		"style": f.style,
+		"reset": f.reset,
+		"strip": strip,
+		"width": width,
+	}
+}
+
+// ---
+
+type funcs struct {
+	o options
+}
+
+func (f funcs) fg(color, value any) (string, error) {
+	c, err := parseColor(color)
+	if err != nil {
+		return "", err
+	}
+
+	return f.render(sgr.Style{Foreground: c}, value), nil
+}
+
+func (f funcs) bg(color, value any) (string, error) {
+	c, err := parseColor(color)
+	if err != nil {
+		return "", err
+	}
+
+	return f.render(sgr.Style{Background: c}, value), nil
+}
+
+func (f funcs) ul(color, value any) (string, error) {
+	c, err := parseColor(color)
+	if err != nil {
+		return "", err
+	}
+
+	return f.render(sgr.Style{UnderlineColor: c, Modes: sgr.Underlined.ModeSet()}, value), nil
+}
+
+func (f funcs) bold(value any) string {
+	return f.render(sgr.Style{Modes: sgr.Bold.ModeSet()}, value)
+}
+
+func (f funcs) style(text string, value any) (string, error) {
+	style, err := sgr.ParseStyle(text)
+	if err != nil {
+		return "", err
+	}
+
+	return f.render(style, value), nil
+}
+
+func (f funcs) reset() string {
+	if f.o.profile == sgr.ColorProfileNone {
+		return ""
+	}
+
+	return string(sgr.Sequence{sgr.ResetAll}.Render(nil))
+}
+
+// render renders value with style applied beneath the styles value already contains,
+// so that the resulting text restores the style after each nested styled block ends.
+func (f funcs) render(style sgr.Style, value any) string {
+	text := sgr.ParseStyledText([]byte(fmt.Sprint(value)))
+	if f.o.profile == sgr.ColorProfileNone {
+		return text.Plain()
+	}
+
+	text = text.WithBaseStyle(style)
+	if f.o.profile == sgr.ColorProfileTrueColor {
+		return text.String()
+	}
+
+	var result sgr.StyledText
+	plain := text.Plain()
+	for _, run := range text.Runs() {
+		result = result.Append(plain[run.Start:run.End], f.o.profile.ConvertStyle(run.Style))
+	}
+
+	return result.String()
+}
+
+// ---
+
+func strip(value any) string {
+	return sgr.ParseStyledText([]byte(fmt.Sprint(value))).Plain()
+}
+
+func width(value any) int {
+	return sgr.Width([]byte(fmt.Sprint(value)))
+}
+
+func parseColor(value any) (sgr.Color, error) {
+	switch value := value.(type) {
+	case sgr.IntoColor:
+		return value.Color(), nil
+	case int:
+		if value < 0 || value > 255 {
+			return 0, sgr.ErrInvalidColorText{Value: fmt.Sprint(value)}
+		}
+
+		return sgr.PaletteColor(value).Color(), nil
+	default:
+		var c sgr.Color
+		err := c.UnmarshalText([]byte(fmt.Sprint(value)))
+		if err != nil {
+			return 0, err
+		}
+		if c.IsZero() {
+			return 0, sgr.ErrInvalidColorText{}
+		}
+
+		return c, nil
+	}
+}
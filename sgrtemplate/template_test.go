@@ -0,0 +1,70 @@
+package sgrtemplate_test
+
+import (
+	htmltemplate "html/template"
+	"strings"
+	"testing"
+	"text/template"
+
+	. "github.com/pamburus/go-tst/tst"
+
+	"github.com/pamburus/go-ansi-esc/sgr"
+	"github.com/pamburus/go-ansi-esc/sgrtemplate"
+)
+
+func TestFuncMap(tt *testing.T) {
+	t := New(tt)
+
+	execute := func(t Test, text string, data any, options ...sgrtemplate.Option) string {
+		tpl := template.Must(template.New("").Funcs(sgrtemplate.FuncMap(options...)).Parse(text))
+		var buf strings.Builder
+		t.Expect(tpl.Execute(&buf, data)).ToSucceed()
+
+		return buf.String()
+	}
+
+	t.Run("Colors", func(t Test) {
+		t.Expect(execute(t, `{{ fg "red" "a" }}|{{ bg 42 "b" }}|{{ .Name | ul "#ff8000" }}`, map[string]string{"Name": "c"})).
+			ToEqual("\x1b[31ma\x1b[0m|\x1b[48;5;42mb\x1b[0m|\x1b[58;2;255;128;0;4mc\x1b[0m")
+		t.Expect(execute(t, `{{ ul "red" (style "curly-underlined" "x") }}`, nil)).ToEqual("\x1b[58;5;1;4:3mx\x1b[0m")
+		t.Expect(execute(t, `{{ fg .Color 1 }}`, map[string]any{"Color": sgr.BrightBlue})).ToEqual("\x1b[94m1\x1b[0m")
+	})
+
+	t.Run("Style", func(t Test) {
+		t.Expect(execute(t, `{{ bold "a" }} {{ style "italic green on blue" "b" }}{{ reset }}`, nil)).
+			ToEqual("\x1b[1ma\x1b[0m \x1b[44;32;3mb\x1b[0m\x1b[0m")
+	})
+
+	t.Run("Nesting", func(t Test) {
+		t.Expect(execute(t, `{{ fg "red" (printf "a %s b" (bold (bg "blue" "x"))) }}`, nil)).
+			ToEqual("\x1b[31ma \x1b[44;1mx\x1b[49;22m b\x1b[0m")
+		t.Expect(execute(t, `{{ style "u" (printf "%s-%s" (fg "red" "x") (fg "green" "y")) }}`, nil)).
+			ToEqual("\x1b[31;4mx\x1b[39m-\x1b[32my\x1b[0m")
+	})
+
+	t.Run("Plain", func(t Test) {
+		t.Expect(execute(t, `{{ $s := fg "red" (bold "héllo") }}{{ strip $s }} {{ width $s }}`, nil)).ToEqual("héllo 5")
+	})
+
+	t.Run("ColorProfile", func(t Test) {
+		t.Expect(execute(t, `{{ fg "#ff0000" "a" }}`, nil, sgrtemplate.WithColorProfile(sgr.ColorProfile16))).ToEqual("\x1b[91ma\x1b[0m")
+		t.Expect(execute(t, `{{ ul "#ff0000" "a" }}{{ ul 200 "b" }}`, nil, sgrtemplate.WithColorProfile(sgr.ColorProfile16))).
+			ToEqual("\x1b[4ma\x1b[0m\x1b[4mb\x1b[0m")
+		t.Expect(execute(t, `{{ ul "#ff0000" "a" }}`, nil, sgrtemplate.WithColorProfile(sgr.ColorProfile256))).ToEqual("\x1b[58;5;196;4ma\x1b[0m")
+		t.Expect(execute(t, `{{ style "bold red" "a" }}{{ reset }}`, nil, sgrtemplate.WithColorProfile(sgr.ColorProfileNone))).ToEqual("a")
+	})
+
+	t.Run("Errors", func(t Test) {
+		for _, text := range []string{`{{ fg "nope" "a" }}`, `{{ bg 256 "a" }}`, `{{ ul "" "a" }}`, `{{ style "nope" "a" }}`} {
+			tpl := template.Must(template.New("").Funcs(sgrtemplate.FuncMap()).Parse(text))
+			t.Expect(tpl.Execute(&strings.Builder{}, nil)).ToFailWith(sgr.ErrInvalidColorText{})
+		}
+	})
+
+	t.Run("HTML", func(t Test) {
+		tpl := htmltemplate.Must(htmltemplate.New("").Funcs(sgrtemplate.FuncMap()).Parse(`<b>{{ fg "red" .Name }}</b>`))
+		var buf strings.Builder
+		t.Expect(tpl.Execute(&buf, map[string]string{"Name": "<x>"})).ToSucceed()
+		t.Expect(buf.String()).ToEqual("<b>\x1b[31m&lt;x&gt;\x1b[0m</b>")
+	})
+}
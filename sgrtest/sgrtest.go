@@ -0,0 +1,210 @@
+// Package sgrtest provides test helpers comparing output containing CSI/SGR sequences
+// by its plain text and effective per-character style instead of raw bytes.
+//
+// So "\x1b[1;31mx\x1b[0m" and "\x1b[31;1mx\x1b[m" are considered equal,
+// as well as outputs differing only in redundant sequences that do not change the effective style.
+// Escape sequences other than SGR are ignored.
+//
+// Golden files are stored in the annotated form produced by sgr.FormatDebug and can be updated
+// by running tests with the -update flag defined by the test package or with SGRTEST_UPDATE environment variable set to true.
+package sgrtest
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/pamburus/go-ansi-esc/sgr"
+)
+
+// ---
+
+// Equal returns true if want and got have the same plain text and the same effective style of each character.
+func Equal(want, got []byte) bool {
+	return equal(sgr.ParseStyledText(want), sgr.ParseStyledText(got))
+}
+
+// Diff returns a human-readable description of differences between want and got
+// or an empty string if they are equal in terms of Equal.
+//
+// For each differing line it shows want and got side by side in two columns,
+// first in the annotated form produced by sgr.FormatDebug
+// and then as plain text with carets below marking characters that differ in text or style.
+func Diff(want, got []byte) string {
+	w := sgr.ParseStyledText(want)
+	g := sgr.ParseStyledText(got)
+	if equal(w, g) {
+		return ""
+	}
+
+	wl := splitLines(w)
+	gl := splitLines(g)
+
+	var sb strings.Builder
+	for i := 0; i < max(len(wl), len(gl)); i++ {
+		var wline, gline sgr.StyledText
+		if i < len(wl) {
+			wline = wl[i]
+		}
+		if i < len(gl) {
+			gline = gl[i]
+		}
+
+		if equal(wline, gline) {
+			continue
+		}
+
+		wmarks, gmarks := marks(wline, gline)
+
+		rows := [][2]string{
+			{"want", "got"},
+			{sgr.FormatDebug(wline.Bytes()), sgr.FormatDebug(gline.Bytes())},
+			{wline.Plain(), gline.Plain()},
+			{wmarks, gmarks},
+		}
+
+		width := 0
+		for _, row := range rows {
+			width = max(width, sgr.Width([]byte(row[0])))
+		}
+
+		fmt.Fprintf(&sb, "line %d:\n", i+1)
+		for _, row := range rows {
+			left := string(sgr.PadRight([]byte(row[0]), width))
+			sb.WriteString(strings.TrimRight("  "+left+" | "+row[1], " "))
+			sb.WriteByte('\n')
+		}
+	}
+
+	return sb.String()
+}
+
+// AssertEqual reports a test error with the result of Diff if want and got are not equal in terms of Equal.
+// It returns true if they are equal.
+func AssertEqual(tb testing.TB, want, got []byte) bool {
+	tb.Helper()
+
+	diff := Diff(want, got)
+	if diff != "" {
+		tb.Errorf("styled output mismatch:\n%s", diff)
+
+		return false
+	}
+
+	return true
+}
+
+// AssertGolden compares got with the contents of the golden file at the given path using AssertEqual.
+// If the test package defines -update boolean flag and it is set or SGRTEST_UPDATE environment variable is set to true,
+// the golden file is written instead.
+// The flag is not defined by this package to avoid conflicts with test packages defining it for their own golden files.
+// It returns true if the contents are equal or the file is updated successfully.
+func AssertGolden(tb testing.TB, path string, got []byte) bool {
+	tb.Helper()
+
+	if updating() {
+		err := os.MkdirAll(filepath.Dir(path), 0o755)
+		if err == nil {
+			err = os.WriteFile(path, []byte(sgr.FormatDebug(got)), 0o644)
+		}
+		if err != nil {
+			tb.Errorf("failed to update golden file: %v", err)
+
+			return false
+		}
+
+		return true
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		tb.Errorf("failed to read golden file: %v", err)
+
+		return false
+	}
+
+	want, err := sgr.ParseDebug(string(data))
+	if err != nil {
+		tb.Errorf("failed to parse golden file %s: %v", path, err)
+
+		return false
+	}
+
+	return AssertEqual(tb, want, got)
+}
+
+// ---
+
+// updating returns true if golden files should be updated.
+func updating() bool {
+	if f := flag.Lookup("update"); f != nil {
+		if v, err := strconv.ParseBool(f.Value.String()); err == nil && v {
+			return true
+		}
+	}
+
+	v, _ := strconv.ParseBool(os.Getenv("SGRTEST_UPDATE"))
+
+	return v
+}
+
+func equal(a, b sgr.StyledText) bool {
+	return a.Plain() == b.Plain() && slices.Equal(a.Runs(), b.Runs())
+}
+
+func splitLines(t sgr.StyledText) []sgr.StyledText {
+	var lines []sgr.StyledText
+	text := t.Plain()
+	start := 0
+	for {
+		i := strings.IndexByte(text[start:], '\n')
+		if i < 0 {
+			return append(lines, t.Slice(start, len(text)))
+		}
+
+		lines = append(lines, t.Slice(start, start+i))
+		start += i + 1
+	}
+}
+
+// marks returns lines of carets marking characters of a and b that differ in text or style.
+func marks(a, b sgr.StyledText) (string, string) {
+	at, bt := []rune(a.Plain()), []rune(b.Plain())
+	as, bs := runeStyles(a), runeStyles(b)
+
+	differs := func(i int) bool {
+		return i >= len(at) || i >= len(bt) || at[i] != bt[i] || as[i] != bs[i]
+	}
+
+	mark := func(text []rune) string {
+		var sb strings.Builder
+		for i, r := range text {
+			c := " "
+			if differs(i) {
+				c = "^"
+			}
+			sb.WriteString(strings.Repeat(c, sgr.Width([]byte(string(r)))))
+		}
+
+		return strings.TrimRight(sb.String(), " ")
+	}
+
+	return mark(at), mark(bt)
+}
+
+func runeStyles(t sgr.StyledText) []sgr.Style {
+	text := t.Plain()
+	styles := make([]sgr.Style, 0, len(text))
+	for _, run := range t.Runs() {
+		for range text[run.Start:run.End] {
+			styles = append(styles, run.Style)
+		}
+	}
+
+	return styles
+}
@@ -0,0 +1,98 @@
+package sgrtest_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/pamburus/go-tst/tst"
+
+	"github.com/pamburus/go-ansi-esc/sgrtest"
+)
+
+func TestEqual(tt *testing.T) {
+	t := New(tt)
+
+	t.Expect(sgrtest.Equal([]byte("\x1b[1;31mx\x1b[0m"), []byte("\x1b[31;1mx\x1b[m"))).ToBeTrue()
+	t.Expect(sgrtest.Equal([]byte("\x1b[1ma\x1b[0m\x1b[1mb\x1b[0m\x1b[32m"), []byte("\x1b[1mab\x1b[0m"))).ToBeTrue()
+	t.Expect(sgrtest.Equal([]byte("\x1b[1ma\x1b[39mb"), []byte("\x1b[1mab"))).ToBeTrue()
+	t.Expect(sgrtest.Equal([]byte("\x1b[1mab"), []byte("\x1b[1ma\x1b[0mb"))).ToEqual(false)
+	t.Expect(sgrtest.Equal([]byte("ab"), []byte("abc"))).ToEqual(false)
+	t.Expect(sgrtest.Diff([]byte("\x1b[1mx"), []byte("\x1b[1mx\x1b[0m"))).ToEqual("")
+}
+
+func TestDiff(tt *testing.T) {
+	t := New(tt)
+
+	t.Expect(sgrtest.Diff(
+		[]byte("same\n\x1b[1mhello\x1b[0m world\nabc"),
+		[]byte("same\n\x1b[1mhel\x1b[31mlo\x1b[0m world\nabd\nextra"),
+	)).ToEqual(
+		"line 2:\n" +
+			"  want                      | got\n" +
+			"  ⟨+Bold⟩hello⟨reset⟩ world | ⟨+Bold⟩hel⟨fg:Red⟩lo⟨reset⟩ world\n" +
+			"  hello world               | hello world\n" +
+			"     ^^                     |    ^^\n" +
+			"line 3:\n" +
+			"  want | got\n" +
+			"  abc  | abd\n" +
+			"  abc  | abd\n" +
+			"    ^  |   ^\n" +
+			"line 4:\n" +
+			"  want | got\n" +
+			"       | extra\n" +
+			"       | extra\n" +
+			"       | ^^^^^\n",
+	)
+}
+
+func TestAssert(tt *testing.T) {
+	t := New(tt)
+
+	t.Run("Equal", func(t Test) {
+		tb := &recorder{TB: tt}
+		t.Expect(sgrtest.AssertEqual(tb, []byte("\x1b[1mx"), []byte("\x1b[1mx"))).ToBeTrue()
+		t.Expect(tb.errors).ToEqual([]string(nil))
+		t.Expect(sgrtest.AssertEqual(tb, []byte("\x1b[1mx"), []byte("x"))).ToEqual(false)
+		t.Expect(tb.errors).ToEqual([]string{
+			"styled output mismatch:\nline 1:\n  want            | got\n  ⟨+Bold⟩x⟨reset⟩ | x\n  x               | x\n  ^               | ^\n",
+		})
+	})
+
+	t.Run("Golden", func(t Test) {
+		path := filepath.Join(t.TempDir(), "testdata", "output.golden")
+		output := []byte("\x1b[1;31mhello\x1b[0m\n")
+
+		tb := &recorder{TB: tt}
+		t.Expect(sgrtest.AssertGolden(tb, path, output)).ToEqual(false)
+		t.Expect(len(tb.errors)).ToEqual(1)
+
+		t.Setenv("SGRTEST_UPDATE", "1")
+		tb = &recorder{TB: tt}
+		t.Expect(sgrtest.AssertGolden(tb, path, []byte("\x1b[1mhello\n"))).ToBeTrue()
+		t.Expect(os.ReadFile(path)).ToSucceed().AndResult().ToEqual([]byte("⟨+Bold⟩hello\n"))
+		t.Expect(sgrtest.AssertGolden(tb, path, output)).ToBeTrue()
+		t.Expect(os.ReadFile(path)).ToSucceed().AndResult().ToEqual([]byte("⟨+Bold fg:Red⟩hello⟨reset⟩\n"))
+		t.Setenv("SGRTEST_UPDATE", "")
+
+		t.Expect(sgrtest.AssertGolden(tb, path, []byte("\x1b[31;1mhello\x1b[m\n"))).ToBeTrue()
+		t.Expect(sgrtest.AssertGolden(tb, path, []byte("\x1b[31mhello\n"))).ToEqual(false)
+		t.Expect(os.WriteFile(path, []byte("⟨nope⟩"), 0o644)).ToSucceed()
+		t.Expect(sgrtest.AssertGolden(tb, path, output)).ToEqual(false)
+		t.Expect(len(tb.errors)).ToEqual(2)
+	})
+}
+
+// ---
+
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}